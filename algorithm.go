@@ -1,11 +1,19 @@
 package jwt
 
 import (
+	"context"
+	"crypto"
+
 	// Load all hashing functions needed.
 	_ "crypto/sha256"
 	_ "crypto/sha512"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
 )
 
+// ErrAlgUnsupported is the error for when an algorithm name is not supported by a constructor.
+var ErrAlgUnsupported = internal.NewError("jwt: unsupported algorithm")
+
 // Algorithm is an algorithm for both signing and verifying a JWT.
 type Algorithm interface {
 	Name() string
@@ -13,3 +21,45 @@ type Algorithm interface {
 	Size() int
 	Verify(headerPayload, sig []byte) error
 }
+
+// PublicKeyAlgorithm is an Algorithm using an asymmetric key,
+// whose public part may be published, such as in a JWK Set.
+type PublicKeyAlgorithm interface {
+	Algorithm
+	Public() crypto.PublicKey
+}
+
+// ContextAlgorithm is an Algorithm whose signing and verification may do I/O,
+// such as remote signers, so it also accepts a context for deadlines and cancellation.
+type ContextAlgorithm interface {
+	Algorithm
+	SignContext(ctx context.Context, headerPayload []byte) ([]byte, error)
+	VerifyContext(ctx context.Context, headerPayload, sig []byte) error
+}
+
+// WithContext adapts alg to a ContextAlgorithm. If alg already is one, it is returned as is.
+// Otherwise, the context is checked before signing and verifying, which are done by alg.
+func WithContext(alg Algorithm) ContextAlgorithm {
+	if ca, ok := alg.(ContextAlgorithm); ok {
+		return ca
+	}
+	return contextAlgorithm{alg}
+}
+
+type contextAlgorithm struct {
+	Algorithm
+}
+
+func (ca contextAlgorithm) SignContext(ctx context.Context, headerPayload []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ca.Sign(headerPayload)
+}
+
+func (ca contextAlgorithm) VerifyContext(ctx context.Context, headerPayload, sig []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ca.Verify(headerPayload, sig)
+}
@@ -1,6 +1,10 @@
 package jwt
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
 
 // Audience is a special claim that may either be
 // a single string or an array of strings, as per the RFC 7519.
@@ -19,6 +23,7 @@ func (a Audience) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements an unmarshaling function for "aud" claim.
+// Values other than a string, an array of strings or null result in an error.
 func (a *Audience) UnmarshalJSON(b []byte) error {
 	var (
 		v   interface{}
@@ -35,9 +40,16 @@ func (a *Audience) UnmarshalJSON(b []byte) error {
 	case []interface{}:
 		aud := make(Audience, len(vv))
 		for i := range vv {
-			aud[i] = vv[i].(string)
+			s, ok := vv[i].(string)
+			if !ok {
+				return internal.Errorf("jwt: %q: %w", "aud", ErrClaimType)
+			}
+			aud[i] = s
 		}
 		*a = aud
+	case nil:
+	default:
+		return internal.Errorf("jwt: %q: %w", "aud", ErrClaimType)
 	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -89,3 +90,15 @@ func checkAudUnmarshal(t *testing.T, got, want jwt.Audience) {
 		t.Errorf("jwt.Audience.Unmarshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
 	}
 }
+
+func TestAudienceUnmarshalInvalid(t *testing.T) {
+	for _, s := range []string{`["foo",1]`, `5`, `true`, `{}`} {
+		t.Run(s, func(t *testing.T) {
+			var aud jwt.Audience
+			err := json.Unmarshal([]byte(s), &aud)
+			if want, got := jwt.ErrClaimType, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
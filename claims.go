@@ -0,0 +1,231 @@
+package jwt
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrClaimNotFound is the error for when a claim is missing.
+	ErrClaimNotFound = internal.NewError("jwt: claim not found")
+	// ErrClaimType is the error for when a claim has an unexpected type.
+	ErrClaimType = internal.NewError("jwt: claim has an invalid type")
+)
+
+// Claims is a generic JWT payload for when declaring a struct is not desired.
+// It can be used both for signing and, as a pointer, for verifying.
+type Claims map[string]interface{}
+
+// String returns the claim name as a string.
+func (c Claims) String(name string) (string, error) {
+	v, err := c.claim(name)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", claimTypeError(name)
+	}
+	return s, nil
+}
+
+// Int64 returns the claim name as an integer.
+// Numbers with a fractional part are reported as a type mismatch.
+func (c Claims) Int64(name string) (int64, error) {
+	v, err := c.claim(name)
+	if err != nil {
+		return 0, err
+	}
+	switch vv := v.(type) {
+	case float64:
+		// float64(math.MaxInt64) rounds up to 2^63, which doesn't fit in an int64.
+		if vv != math.Trunc(vv) || vv >= math.MaxInt64 || vv < math.MinInt64 {
+			return 0, claimTypeError(name)
+		}
+		return int64(vv), nil
+	case json.Number:
+		n, err := vv.Int64()
+		if err != nil {
+			return 0, claimTypeError(name)
+		}
+		return n, nil
+	case int:
+		return int64(vv), nil
+	case int64:
+		return vv, nil
+	}
+	return 0, claimTypeError(name)
+}
+
+// Time returns the claim name as a Time.
+// Fractional NumericDates keep their sub-second precision.
+func (c Claims) Time(name string) (*Time, error) {
+	v, err := c.claim(name)
+	if err != nil {
+		return nil, err
+	}
+	switch vv := v.(type) {
+	case Time:
+		return &vv, nil
+	case *Time:
+		return vv, nil
+	case time.Time:
+		return NumericDate(vv), nil
+	case float64:
+		tt, ok := floatNumericDate(vv)
+		if !ok {
+			return nil, claimTypeError(name)
+		}
+		return &Time{tt}, nil
+	case json.Number:
+		tt, err := parseNumericDate(vv.String())
+		if err != nil {
+			return nil, claimTypeError(name)
+		}
+		return &Time{tt}, nil
+	}
+	unix, err := c.Int64(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Time{time.Unix(unix, 0)}, nil
+}
+
+// StringSlice returns the claim name as a slice of strings.
+func (c Claims) StringSlice(name string) ([]string, error) {
+	v, err := c.claim(name)
+	if err != nil {
+		return nil, err
+	}
+	switch vv := v.(type) {
+	case []string:
+		return vv, nil
+	case []interface{}:
+		ss := make([]string, len(vv))
+		for i := range vv {
+			s, ok := vv[i].(string)
+			if !ok {
+				return nil, claimTypeError(name)
+			}
+			ss[i] = s
+		}
+		return ss, nil
+	}
+	return nil, claimTypeError(name)
+}
+
+// Audience returns the "aud" claim, which may either be a string or an array of strings.
+func (c Claims) Audience() (Audience, error) {
+	v, err := c.claim("aud")
+	if err != nil {
+		return nil, err
+	}
+	switch vv := v.(type) {
+	case Audience:
+		return vv, nil
+	case string:
+		return Audience{vv}, nil
+	}
+	ss, err := c.StringSlice("aud")
+	if err != nil {
+		return nil, err
+	}
+	return Audience(ss), nil
+}
+
+// Object returns the claim name as a nested JSON object.
+func (c Claims) Object(name string) (Claims, error) {
+	v, err := c.claim(name)
+	if err != nil {
+		return nil, err
+	}
+	switch vv := v.(type) {
+	case Claims:
+		return vv, nil
+	case map[string]interface{}:
+		return Claims(vv), nil
+	}
+	return nil, claimTypeError(name)
+}
+
+// timeClaims returns the registered time claims holding a Time, keeping their sub-second precision.
+func (c Claims) timeClaims() map[string]time.Time {
+	times := make(map[string]time.Time, len(numericDateClaims))
+	for _, name := range numericDateClaims {
+		switch v := c[name].(type) {
+		case Time:
+			times[name] = v.Time
+		case *Time:
+			if v != nil {
+				times[name] = v.Time
+			}
+		}
+	}
+	return times
+}
+
+// Payload returns the registered claims contained in c.
+// Missing claims are left empty, while claims of unexpected types result in an error.
+func (c Claims) Payload() (*Payload, error) {
+	var (
+		pl  Payload
+		err error
+	)
+	for _, s := range []struct {
+		name string
+		dst  *string
+	}{
+		{"iss", &pl.Issuer},
+		{"sub", &pl.Subject},
+		{"jti", &pl.JWTID},
+	} {
+		if *s.dst, err = c.String(s.name); err != nil && !internal.ErrorIs(err, ErrClaimNotFound) {
+			return nil, err
+		}
+	}
+	for _, t := range []struct {
+		name string
+		dst  **Time
+	}{
+		{"exp", &pl.ExpirationTime},
+		{"nbf", &pl.NotBefore},
+		{"iat", &pl.IssuedAt},
+	} {
+		if *t.dst, err = c.Time(t.name); err != nil && !internal.ErrorIs(err, ErrClaimNotFound) {
+			return nil, err
+		}
+	}
+	if pl.Audience, err = c.Audience(); err != nil && !internal.ErrorIs(err, ErrClaimNotFound) {
+		return nil, err
+	}
+	return &pl, nil
+}
+
+// Validate runs validators against the registered claims contained in c.
+func (c Claims) Validate(vds ...Validator) error {
+	pl, err := c.Payload()
+	if err != nil {
+		return err
+	}
+	for _, vd := range vds {
+		if err = vd(pl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c Claims) claim(name string) (interface{}, error) {
+	v, ok := c[name]
+	if !ok || v == nil {
+		return nil, internal.Errorf("jwt: %q: %w", name, ErrClaimNotFound)
+	}
+	return v, nil
+}
+
+func claimTypeError(name string) error {
+	return internal.Errorf("jwt: %q: %w", name, ErrClaimType)
+}
@@ -0,0 +1,164 @@
+package jwt_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClaims(t *testing.T) {
+	now := time.Now()
+	hs256 := jwt.NewHS256([]byte("secret"))
+	token, err := jwt.Sign(jwt.Claims{
+		"iss":    "gbrlsnchs",
+		"aud":    "https://jwt.io",
+		"exp":    now.Add(time.Hour).Unix(),
+		"roles":  []string{"admin", "user"},
+		"tenant": map[string]interface{}{"id": 1337},
+		"mixed":  []interface{}{"foo", 1},
+		"float":  1.5,
+	}, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cl jwt.Claims
+	if _, err = jwt.Verify(token, hs256, &cl); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("getters", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			get  func() (interface{}, error)
+			want interface{}
+			err  error
+		}{
+			{"String", func() (interface{}, error) { return cl.String("iss") }, "gbrlsnchs", nil},
+			{"String", func() (interface{}, error) { return cl.String("exp") }, "", jwt.ErrClaimType},
+			{"String", func() (interface{}, error) { return cl.String("sub") }, "", jwt.ErrClaimNotFound},
+			{"Int64", func() (interface{}, error) { return cl.Int64("exp") }, now.Add(time.Hour).Unix(), nil},
+			{"Int64", func() (interface{}, error) { return cl.Int64("float") }, int64(0), jwt.ErrClaimType},
+			{"Int64", func() (interface{}, error) { return cl.Int64("iss") }, int64(0), jwt.ErrClaimType},
+			{"StringSlice", func() (interface{}, error) { return cl.StringSlice("roles") }, []string{"admin", "user"}, nil},
+			{"StringSlice", func() (interface{}, error) { return cl.StringSlice("mixed") }, []string(nil), jwt.ErrClaimType},
+			{"Audience", func() (interface{}, error) { return cl.Audience() }, jwt.Audience{"https://jwt.io"}, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := tc.get()
+				if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+					t.Fatalf("jwt.Claims.%s error mismatch (-want +got):\n%s", tc.name, cmp.Diff(want, got))
+				}
+				if want := tc.want; !cmp.Equal(got, want) {
+					t.Errorf("jwt.Claims.%s mismatch (-want +got):\n%s", tc.name, cmp.Diff(want, got))
+				}
+			})
+		}
+	})
+
+	t.Run("Int64 range", func(t *testing.T) {
+		testCases := []struct {
+			v    float64
+			want int64
+			err  error
+		}{
+			{1 << 63, 0, jwt.ErrClaimType},
+			{1 << 62, 1 << 62, nil},
+			{-1 << 63, -1 << 63, nil},
+			{-1 << 64, 0, jwt.ErrClaimType},
+		}
+		for _, tc := range testCases {
+			t.Run("", func(t *testing.T) {
+				got, err := jwt.Claims{"n": tc.v}.Int64("n")
+				if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+					t.Fatalf("jwt.Claims.Int64 error mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+				if want := tc.want; got != want {
+					t.Errorf("jwt.Claims.Int64 mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			})
+		}
+	})
+
+	t.Run("Time range", func(t *testing.T) {
+		for _, v := range []interface{}{1e300, -1e300, float64(1 << 63), json.Number("1e300"), "1700000000"} {
+			if _, err := (jwt.Claims{"exp": v}).Time("exp"); !internal.ErrorIs(err, jwt.ErrClaimType) {
+				t.Errorf("jwt.Claims.Time(%v) error mismatch (-want +got):\n%s", v, cmp.Diff(jwt.ErrClaimType, err))
+			}
+		}
+		exp, err := (jwt.Claims{"exp": 1700000000.5}).Time("exp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := time.Unix(1700000000, 5e8), exp.Time; !got.Equal(want) {
+			t.Errorf("jwt.Claims.Time mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("Object", func(t *testing.T) {
+		tenant, err := cl.Object("tenant")
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := tenant.Int64("id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := int64(1337), id; got != want {
+			t.Errorf("jwt.Claims.Object mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("Time", func(t *testing.T) {
+		exp, err := cl.Time("exp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := now.Add(time.Hour).Unix(), exp.Unix(); got != want {
+			t.Errorf("jwt.Claims.Time mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		testCases := []struct {
+			vds []jwt.Validator
+			err error
+		}{
+			{[]jwt.Validator{jwt.IssuerValidator("gbrlsnchs"), jwt.ExpirationTimeValidator(now)}, nil},
+			{[]jwt.Validator{jwt.AudienceValidator(jwt.Audience{"https://golang.org"})}, jwt.ErrAudValidation},
+			{[]jwt.Validator{jwt.ExpirationTimeValidator(now.Add(2 * time.Hour))}, jwt.ErrExpValidation},
+		}
+		for _, tc := range testCases {
+			t.Run("", func(t *testing.T) {
+				if want, got := tc.err, cl.Validate(tc.vds...); !internal.ErrorIs(got, want) {
+					t.Errorf(cmp.Diff(want, got))
+				}
+			})
+		}
+	})
+
+	t.Run("json.Number", func(t *testing.T) {
+		var cl jwt.Claims
+		dec := json.NewDecoder(strings.NewReader(`{"exp":1700000000,"iss":1}`))
+		dec.UseNumber()
+		if err := dec.Decode(&cl); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cl.Payload(); !internal.ErrorIs(err, jwt.ErrClaimType) {
+			t.Errorf("want %v, got %v", jwt.ErrClaimType, err)
+		}
+		delete(cl, "iss")
+		pl, err := cl.Payload()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := int64(1700000000), pl.ExpirationTime.Unix(); got != want {
+			t.Errorf("jwt.Claims.Payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Codec marshals JSON headers and payloads, and unmarshals payloads.
+// Headers are always unmarshaled with encoding/json.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// DefaultCodec is the Codec used by Sign, Verify, and by any Signer or Verifier without a Codec.
+var DefaultCodec Codec = StdCodec{}
+
+// StdCodec is a Codec that uses encoding/json.
+type StdCodec struct {
+	// UseNumber decodes numbers into interface values as json.Number instead of float64.
+	UseNumber bool
+	// DisallowUnknownFields rejects objects with members not matching any field of the destination struct.
+	DisallowUnknownFields bool
+}
+
+// Marshal marshals v using json.Marshal.
+func (StdCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal unmarshals data into v using a json.Decoder configured according to sc.
+func (sc StdCodec) Unmarshal(data []byte, v interface{}) error {
+	if !sc.UseNumber && !sc.DisallowUnknownFields {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if sc.UseNumber {
+		dec.UseNumber()
+	}
+	if sc.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return ErrNotJSONObject
+	}
+	return nil
+}
+
+func codecOrDefault(c Codec) Codec {
+	if c == nil {
+		return DefaultCodec
+	}
+	return c
+}
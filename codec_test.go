@@ -0,0 +1,118 @@
+package jwt_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+type countingCodec struct {
+	jwt.StdCodec
+	marshals, unmarshals int
+}
+
+func (cc *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	cc.marshals++
+	return cc.StdCodec.Marshal(v)
+}
+
+func (cc *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	cc.unmarshals++
+	return cc.StdCodec.Unmarshal(data, v)
+}
+
+func TestCodec(t *testing.T) {
+	hs256 := jwt.NewHS256([]byte("secret"))
+
+	t.Run("Signer and Verifier", func(t *testing.T) {
+		cc := new(countingCodec)
+		token, err := (&jwt.Signer{Codec: cc}).Sign(tp, hs256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pl testPayload
+		if _, err = (&jwt.Verifier{Codec: cc}).Verify(token, hs256, &pl); err != nil {
+			t.Fatal(err)
+		}
+		if want, got := [2]int{2, 1}, [2]int{cc.marshals, cc.unmarshals}; got != want {
+			t.Errorf("codec calls mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		if want, got := tp, pl; !cmp.Equal(got, want) {
+			t.Errorf("jwt.Verifier.Verify payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("DefaultCodec", func(t *testing.T) {
+		defer func(c jwt.Codec) { jwt.DefaultCodec = c }(jwt.DefaultCodec)
+		cc := new(countingCodec)
+		jwt.DefaultCodec = cc
+		token, err := jwt.Sign(tp, hs256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pl testPayload
+		if _, err = jwt.Verify(token, hs256, &pl); err != nil {
+			t.Fatal(err)
+		}
+		if want, got := [2]int{2, 1}, [2]int{cc.marshals, cc.unmarshals}; got != want {
+			t.Errorf("codec calls mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("UseNumber", func(t *testing.T) {
+		token, err := jwt.Sign(jwt.Claims{"big": int64(1<<62 + 1)}, hs256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cl jwt.Claims
+		vf := jwt.Verifier{Codec: jwt.StdCodec{UseNumber: true}}
+		if _, err = vf.Verify(token, hs256, &cl); err != nil {
+			t.Fatal(err)
+		}
+		if want, got := json.Number("4611686018427387905"), cl["big"]; got != want {
+			t.Errorf("jwt.StdCodec.Unmarshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("DisallowUnknownFields", func(t *testing.T) {
+		token, err := jwt.Sign(tp, hs256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pl jwt.Payload
+		vf := jwt.Verifier{Codec: jwt.StdCodec{DisallowUnknownFields: true}}
+		if _, err = vf.Verify(token, hs256, &pl); err == nil {
+			t.Errorf("jwt.StdCodec accepted unknown fields")
+		}
+
+		// Header members unknown to Header, such as "jku" and "crit", are not rejected.
+		enc := base64.RawURLEncoding
+		hp := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT","jku":"https://example.com/jwks","crit":["exp"],"exp":1}`)) +
+			"." + enc.EncodeToString([]byte(`{"sub":"someone"}`))
+		sig, err := hs256.Sign([]byte(hp))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = vf.Verify([]byte(hp+"."+enc.EncodeToString(sig)), hs256, &pl); err != nil {
+			t.Errorf("jwt.Verifier rejected unknown header members: %v", err)
+		}
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		errCodec := errors.New("codec")
+		_, err := (&jwt.Signer{Codec: failingCodec{errCodec}}).Sign(tp, hs256)
+		if want, got := errCodec, err; !internal.ErrorIs(got, want) {
+			t.Errorf(cmp.Diff(want, got))
+		}
+	})
+}
+
+type failingCodec struct{ err error }
+
+func (fc failingCodec) Marshal(interface{}) ([]byte, error) { return nil, fc.err }
+func (fc failingCodec) Unmarshal([]byte, interface{}) error { return fc.err }
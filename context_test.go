@@ -0,0 +1,74 @@
+package jwt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/google/go-cmp/cmp"
+)
+
+type ctxKey struct{}
+
+// remoteAlg records contexts it receives, as a remote signer would use them.
+type remoteAlg struct {
+	jwt.Algorithm
+	signCtx, verifyCtx context.Context
+}
+
+func (ra *remoteAlg) SignContext(ctx context.Context, headerPayload []byte) ([]byte, error) {
+	ra.signCtx = ctx
+	return ra.Sign(headerPayload)
+}
+
+func (ra *remoteAlg) VerifyContext(ctx context.Context, headerPayload, sig []byte) error {
+	ra.verifyCtx = ctx
+	return ra.Verify(headerPayload, sig)
+}
+
+func TestSignVerifyContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	ra := &remoteAlg{Algorithm: jwt.NewHS256(hmacKey1)}
+	if want, got := jwt.ContextAlgorithm(ra), jwt.WithContext(ra); got != want {
+		t.Errorf("jwt.WithContext wrapped a ContextAlgorithm")
+	}
+
+	token, err := jwt.SignContext(ctx, tp, ra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pl testPayload
+	if _, err = jwt.VerifyContext(ctx, token, ra, &pl); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := tp, pl; !cmp.Equal(got, want) {
+		t.Errorf("jwt.VerifyContext payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	for name, c := range map[string]context.Context{"sign": ra.signCtx, "verify": ra.verifyCtx} {
+		if c == nil || c.Value(ctxKey{}) != "value" {
+			t.Errorf("context wasn't passed when trying to %s", name)
+		}
+	}
+}
+
+func TestSignVerifyContextCanceled(t *testing.T) {
+	hs256 := jwt.NewHS256(hmacKey1)
+	token, err := jwt.Sign(tp, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = jwt.SignContext(ctx, tp, hs256); err != context.Canceled {
+		t.Errorf("jwt.SignContext: want %v, got %v", context.Canceled, err)
+	}
+	var pl testPayload
+	if _, err = jwt.VerifyContext(ctx, token, hs256, &pl); err != context.Canceled {
+		t.Errorf("jwt.VerifyContext: want %v, got %v", context.Canceled, err)
+	}
+	var vf jwt.Verifier
+	if _, err = vf.VerifyContext(ctx, token, hs256, &pl); err != context.Canceled {
+		t.Errorf("jwt.Verifier.VerifyContext: want %v, got %v", context.Canceled, err)
+	}
+}
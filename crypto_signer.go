@@ -0,0 +1,198 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"io"
+	"math/big"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	// ErrCryptoSignerNil is the error for creating a CryptoSigner without a crypto.Signer.
+	ErrCryptoSignerNil = internal.NewError("jwt: crypto.Signer is nil")
+	// ErrCryptoSignerSignature is the error for a signature in an unexpected format returned by a crypto.Signer.
+	ErrCryptoSignerSignature = internal.NewError("jwt: crypto.Signer returned an invalid signature")
+
+	_ ContextAlgorithm = new(CryptoSigner)
+)
+
+// ContextSigner is a crypto.Signer that also accepts a context, such as a client for a remote KMS.
+// CryptoSigner uses it whenever available, so deadlines and cancellation reach the signer.
+type ContextSigner interface {
+	crypto.Signer
+	SignContext(ctx context.Context, rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// CryptoSigner is an algorithm that signs using any crypto.Signer, such as keys held in a HSM,
+// a KMS or a TPM, and verifies using the signer's public key.
+type CryptoSigner struct {
+	name   string
+	signer crypto.Signer
+	verify Algorithm
+	sha    crypto.Hash
+	opts   crypto.SignerOpts
+	pool   *hashPool
+	// ecdsa holds what is needed for converting ECDSA signatures, if any.
+	ecdsa *ecdsaComponents
+}
+
+type ecdsaComponents struct {
+	size int
+	n    *big.Int
+	lowS bool
+}
+
+// NewCryptoSigner creates a new algorithm named name that signs with signer. The name must be one of
+// "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "ES256K", "EdDSA"
+// or "Ed25519", and it must match the signer's public key, which must meet RFC7518KeyPolicy.
+//
+// ECDSA signatures, which crypto.Signer returns ASN.1 DER encoded, are converted to the r||s format
+// required by the RFC 7518.
+func NewCryptoSigner(name string, signer crypto.Signer) (*CryptoSigner, error) {
+	if signer == nil {
+		return nil, ErrCryptoSignerNil
+	}
+	cs := CryptoSigner{name: name, signer: signer}
+	pub := signer.Public()
+	var err error
+	if params, ok := rsaAlgs[name]; ok {
+		k, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return nil, internal.Errorf("jwt: %T for %s: %w", pub, name, ErrKeyType)
+		}
+		if cs.verify, err = NewRSASHA(name, RSAPublicKey(k)); err != nil {
+			return nil, err
+		}
+		cs.sha, cs.opts = params.sha, params.sha
+		if params.pss {
+			cs.opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: params.sha}
+		}
+	} else if params, ok := ecdsaAlgs[name]; ok {
+		k, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, internal.Errorf("jwt: %T for %s: %w", pub, name, ErrKeyType)
+		}
+		if cs.verify, err = NewECDSASHA(name, ECDSAPublicKey(k)); err != nil {
+			return nil, err
+		}
+		cs.sha, cs.opts = params.sha, params.sha
+		cs.ecdsa = &ecdsaComponents{
+			size: byteSize(k.Params().BitSize),
+			n:    k.Params().N,
+			lowS: name == "ES256K",
+		}
+	} else if name == "EdDSA" || name == "Ed25519" {
+		if cs.verify, err = newEdDSA([]func(*EdDSA){EdDSAPublicKey(pub)}); err != nil {
+			return nil, err
+		}
+		if name == "Ed25519" {
+			if _, ok := pub.(ed25519.PublicKey); !ok {
+				return nil, internal.Errorf("jwt: %T for %s: %w", pub, name, ErrKeyType)
+			}
+		}
+		cs.opts = crypto.Hash(0) // EdDSA signs messages instead of digests
+	} else {
+		return nil, internal.Errorf("jwt: %q: %w", name, ErrAlgUnsupported)
+	}
+	if cs.sha != 0 {
+		cs.pool = newHashPool(cs.sha.New)
+	}
+	return &cs, nil
+}
+
+// Name returns the algorithm's name.
+func (cs *CryptoSigner) Name() string {
+	return cs.name
+}
+
+// Sign signs headerPayload using the crypto.Signer.
+func (cs *CryptoSigner) Sign(headerPayload []byte) ([]byte, error) {
+	return cs.SignContext(context.Background(), headerPayload)
+}
+
+// SignContext signs headerPayload using the crypto.Signer, passing ctx to it
+// if it is a ContextSigner. Otherwise, ctx is only checked before signing.
+func (cs *CryptoSigner) SignContext(ctx context.Context, headerPayload []byte) ([]byte, error) {
+	digest := headerPayload
+	if cs.pool != nil {
+		var err error
+		if digest, err = cs.pool.sign(headerPayload); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		sig []byte
+		err error
+	)
+	if s, ok := cs.signer.(ContextSigner); ok {
+		sig, err = s.SignContext(ctx, rand.Reader, digest, cs.opts)
+	} else {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		sig, err = cs.signer.Sign(rand.Reader, digest, cs.opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cs.ecdsa != nil {
+		return cs.ecdsa.fromDER(sig)
+	}
+	return sig, nil
+}
+
+// Public returns the signer's public key.
+func (cs *CryptoSigner) Public() crypto.PublicKey {
+	return cs.signer.Public()
+}
+
+// Size returns the signature's byte size.
+func (cs *CryptoSigner) Size() int {
+	return cs.verify.Size()
+}
+
+// Verify verifies a signature based on headerPayload using the signer's public key.
+func (cs *CryptoSigner) Verify(headerPayload, sig []byte) error {
+	return cs.verify.Verify(headerPayload, sig)
+}
+
+// VerifyContext verifies a signature the same way Verify does, which needs no I/O,
+// so ctx is only checked beforehand.
+func (cs *CryptoSigner) VerifyContext(ctx context.Context, headerPayload, sig []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return cs.Verify(headerPayload, sig)
+}
+
+// fromDER converts an ASN.1 DER encoded ECDSA signature to r||s.
+func (ec *ecdsaComponents) fromDER(der []byte) ([]byte, error) {
+	var rs struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &rs)
+	if err != nil {
+		return nil, internal.Errorf("jwt: %v: %w", err, ErrCryptoSignerSignature)
+	}
+	if len(rest) > 0 || rs.R.Sign() <= 0 || rs.S.Sign() <= 0 {
+		return nil, ErrCryptoSignerSignature
+	}
+	if ec.lowS && rs.S.Cmp(new(big.Int).Rsh(ec.n, 1)) > 0 {
+		rs.S.Sub(ec.n, rs.S)
+	}
+	rb, sb := rs.R.Bytes(), rs.S.Bytes()
+	if len(rb) > ec.size || len(sb) > ec.size {
+		return nil, ErrCryptoSignerSignature
+	}
+	sig := make([]byte, 2*ec.size)
+	copy(sig[ec.size-len(rb):], rb)
+	copy(sig[2*ec.size-len(sb):], sb)
+	return sig, nil
+}
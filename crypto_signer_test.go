@@ -0,0 +1,194 @@
+package jwt_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeSigner hides the concrete type of a private key, as a KMS client would.
+type fakeSigner struct {
+	crypto.Signer
+	sig []byte // overrides signatures, if set
+}
+
+func (fs fakeSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if fs.sig != nil {
+		return fs.sig, nil
+	}
+	return fs.Signer.Sign(rand, digest, opts)
+}
+
+// ed448Signer stands for an external Ed448 signer, such as an HSM, which always
+// returns the signature of ed448Token, since Ed448 signing isn't implemented here.
+type ed448Signer struct{}
+
+func (ed448Signer) Public() crypto.PublicKey { return ed448PublicKey1 }
+
+func (ed448Signer) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	sig := ed448Token[bytes.LastIndexByte(ed448Token, '.')+1:]
+	return base64.RawURLEncoding.DecodeString(string(sig))
+}
+
+type fakeContextSigner struct {
+	fakeSigner
+	ctx context.Context
+}
+
+func (fs *fakeContextSigner) SignContext(ctx context.Context, rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	fs.ctx = ctx
+	return fs.Sign(rand, digest, opts)
+}
+
+func TestCryptoSigner(t *testing.T) {
+	testCases := []struct {
+		name      string
+		signer    crypto.Signer
+		verifyAlg jwt.Algorithm
+	}{
+		{"RS256", rsaPrivateKey1, jwt.NewRS256(jwt.RSAPublicKey(rsaPublicKey1))},
+		{"PS384", rsaPrivateKey1, jwt.NewPS384(jwt.RSAPublicKey(rsaPublicKey1))},
+		{"ES256", es256PrivateKey1, jwt.NewES256(jwt.ECDSAPublicKey(es256PublicKey1))},
+		{"ES512", es512PrivateKey1, jwt.NewES512(jwt.ECDSAPublicKey(es512PublicKey1))},
+		{"ES256K", es256kPrivateKey1, jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey1), jwt.ECDSALowS())},
+		{"EdDSA", ed25519PrivateKey1, jwt.NewEdDSA(jwt.EdDSAPublicKey(ed25519PublicKey1))},
+		{"Ed25519", ed25519PrivateKey1, jwt.NewEd25519(jwt.Ed25519PublicKey(ed25519PublicKey1))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := jwt.NewCryptoSigner(tc.name, fakeSigner{Signer: tc.signer})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.verifyAlg.Size(), cs.Size(); got != want {
+				t.Errorf("want %d, got %d", want, got)
+			}
+			token, err := jwt.Sign(tp, cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, alg := range []jwt.Algorithm{cs, tc.verifyAlg} {
+				var pl testPayload
+				if _, err = jwt.Verify(token, alg, &pl, jwt.ValidateHeader); err != nil {
+					t.Fatal(err)
+				}
+				if want, got := tp, pl; !cmp.Equal(got, want) {
+					t.Errorf("jwt.Verify payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}
+		})
+	}
+}
+
+func TestCryptoSignerEd448(t *testing.T) {
+	cs, err := jwt.NewCryptoSigner("EdDSA", ed448Signer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Sign(jwt.Payload{Subject: "ed448"}, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := ed448Token, token; !bytes.Equal(got, want) {
+		t.Errorf("jwt.Sign mismatch (-want +got):\n%s", cmp.Diff(string(want), string(got)))
+	}
+	if _, err = jwt.Verify(token, jwt.NewEdDSA(jwt.EdDSAPublicKey(ed448PublicKey1)), new(jwt.Payload)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewCryptoSigner(t *testing.T) {
+	testCases := []struct {
+		name   string
+		signer crypto.Signer
+		err    error
+	}{
+		{"RS256", nil, jwt.ErrCryptoSignerNil},
+		{"HS256", rsaPrivateKey1, jwt.ErrAlgUnsupported},
+		{"RS256", es256PrivateKey1, jwt.ErrKeyType},
+		{"ES256", rsaPrivateKey1, jwt.ErrKeyType},
+		{"ES384", es256PrivateKey1, jwt.ErrECDSACurve},
+		{"EdDSA", rsaPrivateKey1, jwt.ErrKeyType},
+		{"Ed25519", ed448Signer{}, jwt.ErrKeyType},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := jwt.NewCryptoSigner(tc.name, tc.signer)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.NewCryptoSigner err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestCryptoSignerDER(t *testing.T) {
+	testCases := []struct {
+		name string
+		sig  []byte
+		err  error
+	}{
+		{"not DER", []byte("signature"), jwt.ErrCryptoSignerSignature},
+		{"trailing data", []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x00}, jwt.ErrCryptoSignerSignature},
+		{"zero", []byte{0x30, 0x06, 0x02, 0x01, 0x00, 0x02, 0x01, 0x01}, jwt.ErrCryptoSignerSignature},
+		{"small", []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x02}, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := jwt.NewCryptoSigner("ES256", fakeSigner{Signer: es256PrivateKey1, sig: tc.sig})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := cs.Sign([]byte("header.payload"))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.CryptoSigner.Sign err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			// Components are left-padded to the curve's size.
+			if want, got := 64, len(sig); got != want {
+				t.Fatalf("want %d, got %d", want, got)
+			}
+			if r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]); r.Int64() != 1 || s.Int64() != 2 {
+				t.Errorf("want r=1 and s=2, got r=%v and s=%v", r, s)
+			}
+		})
+	}
+}
+
+func TestCryptoSignerContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	t.Run("ContextSigner", func(t *testing.T) {
+		fs := &fakeContextSigner{fakeSigner: fakeSigner{Signer: es256PrivateKey1}}
+		cs, err := jwt.NewCryptoSigner("ES256", fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = cs.SignContext(ctx, []byte("header.payload")); err != nil {
+			t.Fatal(err)
+		}
+		if fs.ctx == nil || fs.ctx.Value(ctxKey{}) != "value" {
+			t.Errorf("context wasn't passed to the signer")
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		cs, err := jwt.NewCryptoSigner("ES256", fakeSigner{Signer: es256PrivateKey1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err = cs.SignContext(ctx, []byte("header.payload")); err != context.Canceled {
+			t.Errorf("want %v, got %v", context.Canceled, err)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+// +build go1.24
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"math/big"
+)
+
+const ecdsaDeterministicSupported = true
+
+// signDeterministic signs sum with a nonce derived from the private key and sum as per the RFC 6979.
+// Since Go 1.24, crypto/ecdsa does so, in constant time, when no source of randomness is passed.
+func signDeterministic(priv *ecdsa.PrivateKey, sum []byte, h crypto.Hash) (r, s *big.Int, err error) {
+	der, err := priv.Sign(nil, sum, h)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rs struct {
+		R, S *big.Int
+	}
+	if _, err = asn1.Unmarshal(der, &rs); err != nil {
+		return nil, nil, err
+	}
+	return rs.R, rs.S, nil
+}
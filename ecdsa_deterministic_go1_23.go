@@ -0,0 +1,15 @@
+// +build !go1.24
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"math/big"
+)
+
+const ecdsaDeterministicSupported = false
+
+func signDeterministic(*ecdsa.PrivateKey, []byte, crypto.Hash) (r, s *big.Int, err error) {
+	return nil, nil, ErrECDSADeterministic
+}
@@ -0,0 +1,61 @@
+// +build go1.24
+
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/google/go-cmp/cmp"
+)
+
+// Test vectors from the RFC 6979, section A.2.5 and A.2.6, for the message "sample".
+func TestECDSADeterministic(t *testing.T) {
+	testCases := []struct {
+		builder func(...func(*jwt.ECDSASHA)) *jwt.ECDSASHA
+		curve   elliptic.Curve
+		d       string
+		sig     string
+	}{
+		{
+			jwt.NewES256,
+			elliptic.P256(),
+			"C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			"EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716" +
+				"F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+		},
+		{
+			jwt.NewES384,
+			elliptic.P384(),
+			"6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5",
+			"94EDBB92A5ECB8AAD4736E56C691916B3F88140666CE9FA73D64C4EA95AD133C81A648152E44ACF96E36DD1E80FABE46" +
+				"99EF4AEB15F178CEA1FE40DB2603138F130E740A19624526203B6351D0A3A94FA329C145786E679E7B82C71A38628AC8",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.curve.Params().Name, func(t *testing.T) {
+			priv := &ecdsa.PrivateKey{D: new(big.Int)}
+			priv.D.SetString(tc.d, 16)
+			priv.Curve = tc.curve
+			priv.X, priv.Y = tc.curve.ScalarBaseMult(priv.D.Bytes())
+
+			es := tc.builder(jwt.ECDSAPrivateKey(priv), jwt.ECDSADeterministic())
+			for i := 0; i < 2; i++ {
+				sig, err := es.Sign([]byte("sample"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want, got := tc.sig, fmt.Sprintf("%X", sig); got != want {
+					t.Fatalf("jwt.ECDSASHA.Sign mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+				if err = es.Verify([]byte("sample"), encodeSig(sig)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
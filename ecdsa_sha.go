@@ -3,6 +3,7 @@ package jwt
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"math/big"
 
@@ -16,6 +17,14 @@ var (
 	ErrECDSANilPubKey = internal.NewError("jwt: ECDSA public key is nil")
 	// ErrECDSAVerification is the error for an invalid ECDSA signature.
 	ErrECDSAVerification = internal.NewError("jwt: ECDSA verification failed")
+	// ErrECDSACurve is the error for an ECDSA key whose curve doesn't match the algorithm.
+	ErrECDSACurve = internal.NewError("jwt: ECDSA curve doesn't match algorithm")
+	// ErrECDSADeterministic is the error for deterministic signatures with a Go version older than 1.24,
+	// whose crypto/ecdsa can't derive nonces as per the RFC 6979.
+	ErrECDSADeterministic = internal.NewError("jwt: deterministic ECDSA requires Go 1.24 or newer")
+	// ErrES256KPrivateKey is the error for a private key on the secp256k1 curve,
+	// which is only supported for verification.
+	ErrES256KPrivateKey = internal.NewError("jwt: secp256k1 private keys are not supported")
 
 	_ Algorithm = new(ECDSASHA)
 )
@@ -34,6 +43,30 @@ func ECDSAPublicKey(pub *ecdsa.PublicKey) func(*ECDSASHA) {
 	}
 }
 
+// ECDSALowS is an option to only sign and accept signatures whose S value is at most
+// half the curve order, which makes them non-malleable.
+func ECDSALowS() func(*ECDSASHA) {
+	return func(es *ECDSASHA) {
+		es.lowS = true
+	}
+}
+
+// ECDSADeterministic is an option to sign using nonces derived from the private key and the
+// signed content, as per the RFC 6979, instead of random ones. Signatures are then reproducible,
+// which is useful for golden-file tests. Signing is done by crypto/ecdsa in constant time, which
+// requires Go 1.24 or newer, so older versions result in ErrECDSADeterministic.
+func ECDSADeterministic() func(*ECDSASHA) {
+	return func(es *ECDSASHA) {
+		es.deterministic = true
+	}
+}
+
+// Secp256k1 returns the secp256k1 curve, which is used by ES256K.
+// Its arithmetic is not constant time, so it must only be used with public values.
+func Secp256k1() elliptic.Curve {
+	return internal.Secp256k1()
+}
+
 func byteSize(bitSize int) int {
 	byteSize := bitSize / 8
 	if bitSize%8 > 0 {
@@ -49,11 +82,26 @@ type ECDSASHA struct {
 	pub  *ecdsa.PublicKey
 	sha  crypto.Hash
 	size int
+	lowS bool
+
+	deterministic bool
 
 	pool *hashPool
 }
 
-func newECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash) *ECDSASHA {
+type ecdsaParams struct {
+	sha   crypto.Hash
+	curve func() elliptic.Curve
+}
+
+var ecdsaAlgs = map[string]ecdsaParams{
+	"ES256":  {crypto.SHA256, elliptic.P256},
+	"ES384":  {crypto.SHA384, elliptic.P384},
+	"ES512":  {crypto.SHA512, elliptic.P521},
+	"ES256K": {crypto.SHA256, internal.Secp256k1},
+}
+
+func newECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash) (*ECDSASHA, error) {
 	es := ECDSASHA{
 		name: name,
 		sha:  sha,
@@ -64,29 +112,72 @@ func newECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash) *ECDSASHA
 			opt(&es)
 		}
 	}
+	if es.priv != nil && es.priv.Curve != nil && es.priv.Params().Name == "secp256k1" {
+		// The secp256k1 implementation is not constant time, so it must not handle secrets.
+		return nil, ErrES256KPrivateKey
+	}
+	if es.deterministic && es.priv != nil && !ecdsaDeterministicSupported {
+		return nil, ErrECDSADeterministic
+	}
 	if es.pub == nil {
 		if es.priv == nil {
-			panic(ErrECDSANilPrivKey)
+			return nil, ErrECDSANilPrivKey
 		}
 		es.pub = &es.priv.PublicKey
 	}
+	if es.pub.Curve == nil {
+		return nil, ErrECDSANilPubKey
+	}
 	es.size = byteSize(es.pub.Params().BitSize) * 2
-	return &es
+	return &es, nil
+}
+
+func mustECDSASHA(es *ECDSASHA, err error) *ECDSASHA {
+	if err != nil {
+		panic(err)
+	}
+	return es
+}
+
+// NewECDSASHA creates a new ECDSA-SHA algorithm named name, which must be "ES256", "ES384", "ES512" or "ES256K".
+// Unlike NewES256 and its siblings, it returns an error instead of panicking, and it also checks
+// the key's curve matches the algorithm.
+func NewECDSASHA(name string, opts ...func(*ECDSASHA)) (*ECDSASHA, error) {
+	params, ok := ecdsaAlgs[name]
+	if !ok {
+		return nil, internal.Errorf("jwt: %q: %w", name, ErrAlgUnsupported)
+	}
+	es, err := newECDSASHA(name, opts, params.sha)
+	if err != nil {
+		return nil, err
+	}
+	if err = RFC7518KeyPolicy().Check(name, es.pub); err != nil {
+		return nil, err
+	}
+	return es, nil
 }
 
 // NewES256 creates a new algorithm using ECDSA and SHA-256.
 func NewES256(opts ...func(*ECDSASHA)) *ECDSASHA {
-	return newECDSASHA("ES256", opts, crypto.SHA256)
+	return mustECDSASHA(newECDSASHA("ES256", opts, crypto.SHA256))
 }
 
 // NewES384 creates a new algorithm using ECDSA and SHA-384.
 func NewES384(opts ...func(*ECDSASHA)) *ECDSASHA {
-	return newECDSASHA("ES384", opts, crypto.SHA384)
+	return mustECDSASHA(newECDSASHA("ES384", opts, crypto.SHA384))
 }
 
 // NewES512 creates a new algorithm using ECDSA and SHA-512.
 func NewES512(opts ...func(*ECDSASHA)) *ECDSASHA {
-	return newECDSASHA("ES512", opts, crypto.SHA512)
+	return mustECDSASHA(newECDSASHA("ES512", opts, crypto.SHA512))
+}
+
+// NewES256K creates a new algorithm using ECDSA over the secp256k1 curve and SHA-256, as per the RFC 8812.
+// Keys must use the curve returned by Secp256k1. Since that curve is not implemented in constant time,
+// ES256K only verifies: private keys result in ErrES256KPrivateKey. In order to sign, use a CryptoSigner
+// backed by a vetted secp256k1 implementation, such as a hardware module.
+func NewES256K(opts ...func(*ECDSASHA)) *ECDSASHA {
+	return mustECDSASHA(newECDSASHA("ES256K", opts, crypto.SHA256))
 }
 
 // Name returns the algorithm's name.
@@ -102,6 +193,11 @@ func (es *ECDSASHA) Sign(headerPayload []byte) ([]byte, error) {
 	return es.sign(headerPayload)
 }
 
+// Public returns the ECDSA public key, which is an *ecdsa.PublicKey.
+func (es *ECDSASHA) Public() crypto.PublicKey {
+	return es.pub
+}
+
 // Size returns the signature's byte size.
 func (es *ECDSASHA) Size() int {
 	return es.size
@@ -122,6 +218,9 @@ func (es *ECDSASHA) Verify(headerPayload, sig []byte) (err error) {
 
 	r := big.NewInt(0).SetBytes(sig[:byteSize])
 	s := big.NewInt(0).SetBytes(sig[byteSize:])
+	if es.lowS && s.Cmp(halfOrder(es.pub.Curve)) > 0 {
+		return ErrECDSAVerification
+	}
 	sum, err := es.pool.sign(headerPayload)
 	if err != nil {
 		return err
@@ -137,10 +236,18 @@ func (es *ECDSASHA) sign(headerPayload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	r, s, err := ecdsa.Sign(rand.Reader, es.priv, sum)
+	var r, s *big.Int
+	if es.deterministic {
+		r, s, err = signDeterministic(es.priv, sum, es.sha)
+	} else {
+		r, s, err = ecdsa.Sign(rand.Reader, es.priv, sum)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if es.lowS && s.Cmp(halfOrder(es.priv.Curve)) > 0 {
+		s.Sub(es.priv.Params().N, s)
+	}
 	byteSize := byteSize(es.priv.Params().BitSize)
 	rbytes := r.Bytes()
 	rsig := make([]byte, byteSize)
@@ -151,3 +258,7 @@ func (es *ECDSASHA) sign(headerPayload []byte) ([]byte, error) {
 	copy(ssig[byteSize-len(sbytes):], sbytes)
 	return append(rsig, ssig...), nil
 }
+
+func halfOrder(c elliptic.Curve) *big.Int {
+	return new(big.Int).Rsh(c.Params().N, 1)
+}
@@ -4,7 +4,8 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-
+	"encoding/base64"
+	"math/big"
 	"testing"
 
 	"github.com/gbrlsnchs/jwt/v3"
@@ -22,6 +23,18 @@ var (
 	es512PrivateKey1, es512PublicKey1 = genECDSAKeys(elliptic.P521())
 	es512PrivateKey2, es512PublicKey2 = genECDSAKeys(elliptic.P521())
 
+	es256kPrivateKey1, es256kPublicKey1 = genECDSAKeys(jwt.Secp256k1())
+	es256kPrivateKey2, es256kPublicKey2 = genECDSAKeys(jwt.Secp256k1())
+
+	// ES256K only verifies, so tokens are signed by the private key itself as a crypto.Signer.
+	es256kSigner1 = func() jwt.Algorithm {
+		cs, err := jwt.NewCryptoSigner("ES256K", es256kPrivateKey1)
+		if err != nil {
+			panic(err)
+		}
+		return cs
+	}()
+
 	ecdsaTestCases = []testCase{
 		{
 			alg:       jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey1)),
@@ -203,6 +216,42 @@ var (
 			signErr:     nil,
 			verifyErr:   jwt.ErrECDSAVerification,
 		},
+		{
+			alg:       es256kSigner1,
+			payload:   tp,
+			verifyAlg: jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey1), jwt.ECDSALowS()),
+			wantHeader: jwt.Header{
+				Algorithm: "ES256K",
+				Type:      "JWT",
+			},
+			wantPayload: tp,
+			signErr:     nil,
+			verifyErr:   nil,
+		},
+		{
+			alg:       es256kSigner1,
+			payload:   tp,
+			verifyAlg: jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey2)),
+			wantHeader: jwt.Header{
+				Algorithm: "ES256K",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrECDSAVerification,
+		},
+		{
+			alg:       es256kSigner1,
+			payload:   tp,
+			verifyAlg: jwt.NewES256(jwt.ECDSAPublicKey(es256PublicKey1)),
+			wantHeader: jwt.Header{
+				Algorithm: "ES256K",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrECDSAVerification,
+		},
 	}
 )
 
@@ -254,3 +303,74 @@ func genECDSAKeys(c elliptic.Curve) (*ecdsa.PrivateKey, *ecdsa.PublicKey) {
 	}
 	return priv, &priv.PublicKey
 }
+
+func TestNewECDSASHAByName(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []func(*jwt.ECDSASHA)
+		err  error
+	}{
+		{"ES256", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es256PrivateKey1)}, nil},
+		{"ES384", []func(*jwt.ECDSASHA){jwt.ECDSAPublicKey(es384PublicKey1)}, nil},
+		{"ES512", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es512PrivateKey1)}, nil},
+		{"ES256K", []func(*jwt.ECDSASHA){jwt.ECDSAPublicKey(es256kPublicKey1)}, nil},
+		{"ES384", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es256PrivateKey1)}, jwt.ErrECDSACurve},
+		{"ES256K", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es256PrivateKey1)}, jwt.ErrECDSACurve},
+		{"ES256", []func(*jwt.ECDSASHA){jwt.ECDSAPublicKey(es256kPublicKey1)}, jwt.ErrECDSACurve},
+		{"ES256K", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es256kPrivateKey1)}, jwt.ErrES256KPrivateKey},
+		{"ES256", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es256kPrivateKey1)}, jwt.ErrES256KPrivateKey},
+		{"ES256", []func(*jwt.ECDSASHA){jwt.ECDSAPublicKey(es512PublicKey1)}, jwt.ErrECDSACurve},
+		{"ES256", nil, jwt.ErrECDSANilPrivKey},
+		{"ES256", []func(*jwt.ECDSASHA){jwt.ECDSAPublicKey(&ecdsa.PublicKey{})}, jwt.ErrECDSANilPubKey},
+		{"RS256", []func(*jwt.ECDSASHA){jwt.ECDSAPrivateKey(es256PrivateKey1)}, jwt.ErrAlgUnsupported},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			es, err := jwt.NewECDSASHA(tc.name, tc.opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.NewECDSASHA err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err == nil && es.Name() != tc.name {
+				t.Errorf("want %s, got %s", tc.name, es.Name())
+			}
+		})
+	}
+}
+
+func TestES256KLowS(t *testing.T) {
+	var (
+		hp     = []byte("header.payload")
+		es256k = jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey1))
+		lowS   = jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey1), jwt.ECDSALowS())
+		n      = jwt.Secp256k1().Params().N
+		half   = new(big.Int).Rsh(n, 1)
+	)
+	for i := 0; i < 32; i++ {
+		sig, err := es256kSigner1.Sign(hp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := new(big.Int).SetBytes(sig[32:])
+		if s.Cmp(half) > 0 {
+			t.Fatalf("signature has high S value %x", s)
+		}
+		// Flip S to the equivalent high S value, which is also a valid ECDSA signature.
+		high := make([]byte, 64)
+		copy(high, sig[:32])
+		hs := new(big.Int).Sub(n, s).Bytes()
+		copy(high[64-len(hs):], hs)
+		if err = es256k.Verify(hp, encodeSig(sig)); err != nil {
+			t.Fatal(err)
+		}
+		if err = es256k.Verify(hp, encodeSig(high)); err != nil {
+			t.Fatalf("high S rejected without jwt.ECDSALowS: %v", err)
+		}
+		if err = lowS.Verify(hp, encodeSig(high)); !internal.ErrorIs(err, jwt.ErrECDSAVerification) {
+			t.Fatalf("want %v, got %v", jwt.ErrECDSAVerification, err)
+		}
+	}
+}
+
+func encodeSig(sig []byte) []byte {
+	return []byte(base64.RawURLEncoding.EncodeToString(sig))
+}
@@ -3,6 +3,7 @@
 package jwt
 
 import (
+	"crypto"
 	"crypto/ed25519"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
@@ -15,6 +16,8 @@ var (
 	ErrEd25519NilPubKey = internal.NewError("jwt: Ed25519 public key is nil")
 	// ErrEd25519Verification is the error for when verification with Ed25519 fails.
 	ErrEd25519Verification = internal.NewError("jwt: Ed25519 verification failed")
+	// ErrEd25519KeySize is the error for an Ed25519 key with an invalid size.
+	ErrEd25519KeySize = internal.NewError("jwt: Ed25519 key has an invalid size")
 
 	_ Algorithm = new(Ed25519)
 )
@@ -39,21 +42,43 @@ type Ed25519 struct {
 	pub  ed25519.PublicKey
 }
 
-// NewEd25519 creates a new algorithm using EdDSA and SHA-512.
-func NewEd25519(opts ...func(*Ed25519)) *Ed25519 {
+func newEd25519(opts []func(*Ed25519)) (*Ed25519, error) {
 	var ed Ed25519
 	for _, opt := range opts {
 		if opt != nil {
 			opt(&ed)
 		}
 	}
+	if ed.priv != nil && len(ed.priv) != ed25519.PrivateKeySize {
+		return nil, ErrEd25519KeySize
+	}
+	if ed.pub != nil && len(ed.pub) != ed25519.PublicKeySize {
+		return nil, ErrEd25519KeySize
+	}
 	if ed.pub == nil {
 		if len(ed.priv) == 0 {
-			panic(ErrEd25519NilPrivKey)
+			return nil, ErrEd25519NilPrivKey
 		}
 		ed.pub = ed.priv.Public().(ed25519.PublicKey)
 	}
-	return &ed
+	return &ed, nil
+}
+
+// NewEd25519 creates a new algorithm using EdDSA and SHA-512.
+// It signs tokens as "Ed25519", which is not a registered name, so prefer NewEdDSA
+// when tokens are verified by other libraries.
+func NewEd25519(opts ...func(*Ed25519)) *Ed25519 {
+	ed, err := newEd25519(opts)
+	if err != nil {
+		panic(err)
+	}
+	return ed
+}
+
+// NewEd25519SHA creates a new algorithm using EdDSA and SHA-512, the same way NewEd25519 does,
+// but it returns an error instead of panicking, including for keys with invalid sizes.
+func NewEd25519SHA(opts ...func(*Ed25519)) (*Ed25519, error) {
+	return newEd25519(opts)
 }
 
 // Name returns the algorithm's name.
@@ -61,6 +86,12 @@ func (*Ed25519) Name() string {
 	return "Ed25519"
 }
 
+// Aliases returns other names ValidateHeader accepts for the algorithm.
+// Since the RFC 8037 registers Ed25519 as "EdDSA", tokens from other libraries are also accepted.
+func (*Ed25519) Aliases() []string {
+	return []string{"EdDSA"}
+}
+
 // Sign signs headerPayload using the Ed25519 algorithm.
 func (ed *Ed25519) Sign(headerPayload []byte) ([]byte, error) {
 	if ed.priv == nil {
@@ -69,6 +100,11 @@ func (ed *Ed25519) Sign(headerPayload []byte) ([]byte, error) {
 	return ed25519.Sign(ed.priv, headerPayload), nil
 }
 
+// Public returns the Ed25519 public key, which is an ed25519.PublicKey.
+func (ed *Ed25519) Public() crypto.PublicKey {
+	return ed.pub
+}
+
 // Size returns the signature byte size.
 func (*Ed25519) Size() int {
 	return ed25519.SignatureSize
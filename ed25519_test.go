@@ -96,3 +96,25 @@ func TestNewEd25519(t *testing.T) {
 		})
 	}
 }
+
+func TestNewEd25519SHA(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []func(*jwt.Ed25519)
+		err  error
+	}{
+		{"private key", []func(*jwt.Ed25519){jwt.Ed25519PrivateKey(ed25519PrivateKey1)}, nil},
+		{"public key", []func(*jwt.Ed25519){jwt.Ed25519PublicKey(ed25519PublicKey1)}, nil},
+		{"no key", nil, jwt.ErrEd25519NilPrivKey},
+		{"short private key", []func(*jwt.Ed25519){jwt.Ed25519PrivateKey(ed25519PrivateKey1[:32])}, jwt.ErrEd25519KeySize},
+		{"short public key", []func(*jwt.Ed25519){jwt.Ed25519PublicKey(ed25519PublicKey1[:16])}, jwt.ErrEd25519KeySize},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := jwt.NewEd25519SHA(tc.opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.NewEd25519SHA err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
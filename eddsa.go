@@ -0,0 +1,182 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	// ErrEdDSANilPrivKey is the error for trying to sign a JWT with a nil private key.
+	ErrEdDSANilPrivKey = internal.NewError("jwt: EdDSA private key is nil")
+	// ErrEdDSANilPubKey is the error for trying to verify a JWT with a nil public key.
+	ErrEdDSANilPubKey = internal.NewError("jwt: EdDSA public key is nil")
+	// ErrEdDSAVerification is the error for when verification with EdDSA fails.
+	ErrEdDSAVerification = internal.NewError("jwt: EdDSA verification failed")
+	// ErrEd448KeySize is the error for an Ed448 key with an invalid size.
+	ErrEd448KeySize = internal.NewError("jwt: Ed448 key has an invalid size")
+
+	_ Algorithm = new(EdDSA)
+)
+
+// Ed448PublicKey is an Ed448 public key. Ed448 is only supported for verifying
+// signatures, so there is no private key counterpart.
+type Ed448PublicKey []byte
+
+// EdDSAPrivateKey is an option to set a private key to the EdDSA algorithm.
+// It must be an ed25519.PrivateKey, since Ed448 is verification only.
+func EdDSAPrivateKey(priv crypto.PrivateKey) func(*EdDSA) {
+	return func(ed *EdDSA) {
+		ed.privKey = priv
+	}
+}
+
+// EdDSAPublicKey is an option to set a public key to the EdDSA algorithm.
+// It must either be an ed25519.PublicKey or an Ed448PublicKey.
+func EdDSAPublicKey(pub crypto.PublicKey) func(*EdDSA) {
+	return func(ed *EdDSA) {
+		ed.pubKey = pub
+	}
+}
+
+// EdDSA is the algorithm registered by the RFC 8037, which uses either Ed25519 or Ed448,
+// depending on its key, although Ed448 keys can only verify tokens. Its name is always "EdDSA",
+// but ValidateHeader also accepts "Ed25519" for Ed25519 keys, so tokens signed with the
+// Ed25519 algorithm can still be verified.
+type EdDSA struct {
+	privKey crypto.PrivateKey
+	pubKey  crypto.PublicKey
+
+	crv  string
+	priv []byte
+	pub  []byte
+	size int
+}
+
+func newEdDSA(opts []func(*EdDSA)) (*EdDSA, error) {
+	var ed EdDSA
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&ed)
+		}
+	}
+	switch k := ed.privKey.(type) {
+	case nil:
+	case ed25519.PrivateKey:
+		if len(k) != ed25519.PrivateKeySize {
+			return nil, ErrEd25519KeySize
+		}
+		ed.crv, ed.priv, ed.pub = "Ed25519", k, k[ed25519.SeedSize:]
+	default:
+		return nil, internal.Errorf("jwt: %T for EdDSA: %w", k, ErrKeyType)
+	}
+	var crv string
+	switch k := ed.pubKey.(type) {
+	case nil:
+		crv = ed.crv
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, ErrEd25519KeySize
+		}
+		if ed.priv != nil && ed.crv == "Ed25519" && !bytes.Equal(ed.pub, k) {
+			return nil, internal.Errorf("jwt: Ed25519 public key doesn't match private key: %w", ErrKeyType)
+		}
+		crv, ed.pub = "Ed25519", k
+	case Ed448PublicKey:
+		if len(k) != internal.Ed448PublicKeySize {
+			return nil, ErrEd448KeySize
+		}
+		crv, ed.pub = "Ed448", k
+	default:
+		return nil, internal.Errorf("jwt: %T for EdDSA: %w", k, ErrKeyType)
+	}
+	if ed.priv != nil && crv != ed.crv {
+		return nil, internal.Errorf("jwt: %s private key and %s public key: %w", ed.crv, crv, ErrKeyType)
+	}
+	if ed.pub == nil {
+		return nil, ErrEdDSANilPrivKey
+	}
+	ed.crv = crv
+	ed.size = ed25519.SignatureSize
+	if ed.crv == "Ed448" {
+		ed.size = internal.Ed448SignatureSize
+	}
+	return &ed, nil
+}
+
+// NewEdDSA creates a new EdDSA algorithm, as per the RFC 8037.
+func NewEdDSA(opts ...func(*EdDSA)) *EdDSA {
+	ed, err := newEdDSA(opts)
+	if err != nil {
+		panic(err)
+	}
+	return ed
+}
+
+// NewEdDSASHA creates a new EdDSA algorithm the same way NewEdDSA does, but it returns
+// an error instead of panicking, such as for keys with invalid sizes or mismatched keys.
+func NewEdDSASHA(opts ...func(*EdDSA)) (*EdDSA, error) {
+	return newEdDSA(opts)
+}
+
+// Name returns the algorithm's name, which is always "EdDSA".
+func (*EdDSA) Name() string {
+	return "EdDSA"
+}
+
+// Aliases returns other names ValidateHeader accepts for the algorithm.
+func (ed *EdDSA) Aliases() []string {
+	if ed.crv == "Ed25519" {
+		return []string{"Ed25519"}
+	}
+	return nil
+}
+
+// Curve returns the name of the key's curve, either "Ed25519" or "Ed448",
+// which is used as the "crv" parameter of JWKs.
+func (ed *EdDSA) Curve() string {
+	return ed.crv
+}
+
+// Sign signs headerPayload using Ed25519.
+func (ed *EdDSA) Sign(headerPayload []byte) ([]byte, error) {
+	if ed.priv == nil {
+		return nil, ErrEdDSANilPrivKey
+	}
+	return ed25519.Sign(ed.priv, headerPayload), nil
+}
+
+// Public returns the EdDSA public key, which is either an ed25519.PublicKey or an Ed448PublicKey.
+func (ed *EdDSA) Public() crypto.PublicKey {
+	if ed.crv == "Ed448" {
+		return Ed448PublicKey(ed.pub)
+	}
+	return ed25519.PublicKey(ed.pub)
+}
+
+// Size returns the signature byte size.
+func (ed *EdDSA) Size() int {
+	return ed.size
+}
+
+// Verify verifies a payload and a signature.
+func (ed *EdDSA) Verify(headerPayload, sig []byte) (err error) {
+	if ed.pub == nil {
+		return ErrEdDSANilPubKey
+	}
+	if sig, err = internal.DecodeToBytes(sig); err != nil {
+		return err
+	}
+	var ok bool
+	if ed.crv == "Ed448" {
+		ok = internal.Ed448Verify(ed.pub, headerPayload, sig)
+	} else {
+		ok = ed25519.Verify(ed.pub, headerPayload, sig)
+	}
+	if !ok {
+		return ErrEdDSAVerification
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package jwt_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	// Public keys from the RFC 8032, section 7.4.
+	ed448PublicKey1 = decodeEd448PublicKey("5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180")
+	ed448PublicKey2 = decodeEd448PublicKey("43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480")
+	// ed448Token is signed by the private key of ed448PublicKey1.
+	ed448Token = []byte("eyJhbGciOiJFZERTQSIsInR5cCI6IkpXVCJ9.eyJzdWIiOiJlZDQ0OCJ9." +
+		"aerI2fHa1Jz7qVaSu6tONTxi26RdCOIN16bNTFbAkQV_xhq0dlyfIKFyulUPOdTT0s3KM5D_WNMAOwtl8nDnO0-2Vo4aJA1LxMc6H8dbghFARUTSTzB8UkfVPba6J-gVabz21wUaexUSGYroQc5JOCwA")
+
+	eddsaTestCases = []testCase{
+		{
+			alg:       jwt.NewEdDSA(jwt.EdDSAPrivateKey(ed25519PrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewEdDSA(jwt.EdDSAPublicKey(ed25519PublicKey1)),
+			wantHeader: jwt.Header{
+				Algorithm: "EdDSA",
+				Type:      "JWT",
+			},
+			wantPayload: tp,
+			signErr:     nil,
+			verifyErr:   nil,
+		},
+		{
+			alg:       jwt.NewEdDSA(jwt.EdDSAPrivateKey(ed25519PrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewEdDSA(jwt.EdDSAPublicKey(ed25519PublicKey2)),
+			wantHeader: jwt.Header{
+				Algorithm: "EdDSA",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrEdDSAVerification,
+		},
+	}
+)
+
+func decodeEd448PublicKey(s string) jwt.Ed448PublicKey {
+	pub, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return pub
+}
+
+func TestNewEdDSA(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []func(*jwt.EdDSA)
+		crv  string
+		size int
+		err  error
+	}{
+		{"no key", nil, "", 0, jwt.ErrEdDSANilPrivKey},
+		{"Ed25519 private", []func(*jwt.EdDSA){jwt.EdDSAPrivateKey(ed25519PrivateKey1)}, "Ed25519", 64, nil},
+		{"Ed25519 public", []func(*jwt.EdDSA){jwt.EdDSAPublicKey(ed25519PublicKey1)}, "Ed25519", 64, nil},
+		{"Ed448 public", []func(*jwt.EdDSA){jwt.EdDSAPublicKey(ed448PublicKey1)}, "Ed448", 114, nil},
+		{"Ed448 short", []func(*jwt.EdDSA){jwt.EdDSAPublicKey(ed448PublicKey1[1:])}, "", 0, jwt.ErrEd448KeySize},
+		{"Ed25519 short", []func(*jwt.EdDSA){jwt.EdDSAPrivateKey(ed25519PrivateKey1[1:])}, "", 0, jwt.ErrEd25519KeySize},
+		{"mixed", []func(*jwt.EdDSA){
+			jwt.EdDSAPrivateKey(ed25519PrivateKey1),
+			jwt.EdDSAPublicKey(ed448PublicKey1),
+		}, "", 0, jwt.ErrKeyType},
+		{"wrong type", []func(*jwt.EdDSA){jwt.EdDSAPrivateKey(rsaPrivateKey1)}, "", 0, jwt.ErrKeyType},
+		{"mismatched", []func(*jwt.EdDSA){
+			jwt.EdDSAPrivateKey(ed25519PrivateKey1),
+			jwt.EdDSAPublicKey(ed25519PublicKey2),
+		}, "", 0, jwt.ErrKeyType},
+		{"matching", []func(*jwt.EdDSA){
+			jwt.EdDSAPrivateKey(ed25519PrivateKey1),
+			jwt.EdDSAPublicKey(ed25519PublicKey1),
+		}, "Ed25519", 64, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					if tc.err != nil {
+						t.Fatal("jwt.NewEdDSA didn't panic")
+					}
+					return
+				}
+				err, ok := r.(error)
+				if !ok {
+					t.Fatal("r is not an error")
+				}
+				if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+					t.Fatalf("jwt.NewEdDSA err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}()
+			ed := jwt.NewEdDSA(tc.opts...)
+			if want, got := tc.crv, ed.Curve(); got != want {
+				t.Errorf("want %s, got %s", want, got)
+			}
+			if want, got := tc.size, ed.Size(); got != want {
+				t.Errorf("want %d, got %d", want, got)
+			}
+		})
+	}
+}
+
+func TestNewEdDSASHA(t *testing.T) {
+	if _, err := jwt.NewEdDSASHA(jwt.EdDSAPublicKey(ed448PublicKey1[1:])); !internal.ErrorIs(err, jwt.ErrEd448KeySize) {
+		t.Errorf("want %v, got %v", jwt.ErrEd448KeySize, err)
+	}
+	ed, err := jwt.NewEdDSASHA(jwt.EdDSAPrivateKey(ed25519PrivateKey1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "Ed25519", ed.Curve(); got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestEdDSACompatibility(t *testing.T) {
+	var (
+		legacy = jwt.NewEd25519(jwt.Ed25519PrivateKey(ed25519PrivateKey1))
+		eddsa  = jwt.NewEdDSA(jwt.EdDSAPrivateKey(ed25519PrivateKey1))
+		ed448  = jwt.NewEdDSA(jwt.EdDSAPublicKey(ed448PublicKey1))
+	)
+	testCases := []struct {
+		name      string
+		signAlg   jwt.Algorithm
+		verifyAlg jwt.Algorithm
+		err       error
+	}{
+		{"Ed25519 to EdDSA", legacy, eddsa, nil},
+		{"EdDSA to Ed25519", eddsa, legacy, nil},
+		{"Ed25519 to Ed448", legacy, ed448, jwt.ErrAlgValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := jwt.Sign(jwt.Payload{Subject: "eddsa"}, tc.signAlg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			_, err = jwt.Verify(token, tc.verifyAlg, &pl, jwt.ValidateHeader)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestEd448(t *testing.T) {
+	testCases := []struct {
+		name string
+		pub  jwt.Ed448PublicKey
+		err  error
+	}{
+		{"valid", ed448PublicKey1, nil},
+		{"other key", ed448PublicKey2, jwt.ErrEdDSAVerification},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pl jwt.Payload
+			_, err := jwt.Verify(ed448Token, jwt.NewEdDSA(jwt.EdDSAPublicKey(tc.pub)), &pl, jwt.ValidateHeader)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if tc.err == nil && pl.Subject != "ed448" {
+				t.Errorf("want %q, got %q", "ed448", pl.Subject)
+			}
+		})
+	}
+	t.Run("sign", func(t *testing.T) {
+		_, err := jwt.NewEdDSA(jwt.EdDSAPublicKey(ed448PublicKey1)).Sign(nil)
+		if want, got := jwt.ErrEdDSANilPrivKey, err; !internal.ErrorIs(got, want) {
+			t.Errorf("jwt.EdDSA.Sign err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
@@ -24,11 +24,27 @@ type HMACSHA struct {
 	sha  crypto.Hash
 	size int
 	pool *hashPool
+
+	policy *KeyPolicy
 }
 
-func newHMACSHA(name string, key []byte, sha crypto.Hash) *HMACSHA {
+// HMACKeyPolicy is an option to set the KeyPolicy NewHMACSHA checks the key against,
+// instead of RFC7518KeyPolicy.
+func HMACKeyPolicy(kp KeyPolicy) func(*HMACSHA) {
+	return func(hs *HMACSHA) {
+		hs.policy = &kp
+	}
+}
+
+var hmacHashes = map[string]crypto.Hash{
+	"HS256": crypto.SHA256,
+	"HS384": crypto.SHA384,
+	"HS512": crypto.SHA512,
+}
+
+func newHMACSHA(name string, key []byte, sha crypto.Hash) (*HMACSHA, error) {
 	if len(key) == 0 {
-		panic(ErrHMACMissingKey)
+		return nil, ErrHMACMissingKey
 	}
 	return &HMACSHA{
 		name: name, // cache name
@@ -36,22 +52,52 @@ func newHMACSHA(name string, key []byte, sha crypto.Hash) *HMACSHA {
 		sha:  sha,
 		size: sha.Size(), // cache size
 		pool: newHashPool(func() hash.Hash { return hmac.New(sha.New, key) }),
+	}, nil
+}
+
+func mustHMACSHA(hs *HMACSHA, err error) *HMACSHA {
+	if err != nil {
+		panic(err)
+	}
+	return hs
+}
+
+// NewHMACSHA creates a new HMAC-SHA algorithm named name, which must be "HS256", "HS384" or "HS512".
+// Unlike NewHS256 and its siblings, it returns an error instead of panicking,
+// and it also checks the key meets RFC7518KeyPolicy, or the policy set with HMACKeyPolicy.
+func NewHMACSHA(name string, key []byte, opts ...func(*HMACSHA)) (*HMACSHA, error) {
+	sha, ok := hmacHashes[name]
+	if !ok {
+		return nil, internal.Errorf("jwt: %q: %w", name, ErrAlgUnsupported)
+	}
+	hs, err := newHMACSHA(name, key, sha)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(hs)
+		}
+	}
+	if err = keyPolicyOrDefault(hs.policy).Check(name, key); err != nil {
+		return nil, err
 	}
+	return hs, nil
 }
 
 // NewHS256 creates a new algorithm using HMAC and SHA-256.
 func NewHS256(key []byte) *HMACSHA {
-	return newHMACSHA("HS256", key, crypto.SHA256)
+	return mustHMACSHA(newHMACSHA("HS256", key, crypto.SHA256))
 }
 
 // NewHS384 creates a new algorithm using HMAC and SHA-384.
 func NewHS384(key []byte) *HMACSHA {
-	return newHMACSHA("HS384", key, crypto.SHA384)
+	return mustHMACSHA(newHMACSHA("HS384", key, crypto.SHA384))
 }
 
 // NewHS512 creates a new algorithm using HMAC and SHA-512.
 func NewHS512(key []byte) *HMACSHA {
-	return newHMACSHA("HS512", key, crypto.SHA512)
+	return mustHMACSHA(newHMACSHA("HS512", key, crypto.SHA512))
 }
 
 // Name returns the algorithm's name.
@@ -1,6 +1,7 @@
 package jwt_test
 
 import (
+	"bytes"
 	"reflect"
 	"runtime"
 	"strings"
@@ -171,3 +172,30 @@ func funcName(fn interface{}) string {
 		".",
 	)[2]
 }
+
+func TestNewHMACSHAByName(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  []byte
+		err  error
+	}{
+		{"HS256", bytes.Repeat(hmacKey1, 6), nil},
+		{"HS384", bytes.Repeat(hmacKey1, 8), nil},
+		{"HS512", bytes.Repeat(hmacKey1, 11), nil},
+		{"HS512", hmacKey1, jwt.ErrKeyPolicy},
+		{"HS256", nil, jwt.ErrHMACMissingKey},
+		{"HS1024", hmacKey1, jwt.ErrAlgUnsupported},
+		{"RS256", hmacKey1, jwt.ErrAlgUnsupported},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hs, err := jwt.NewHMACSHA(tc.name, tc.key)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.NewHMACSHA err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err == nil && hs.Name() != tc.name {
+				t.Errorf("want %s, got %s", tc.name, hs.Name())
+			}
+		})
+	}
+}
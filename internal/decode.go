@@ -23,3 +23,25 @@ func DecodeToBytes(enc []byte) ([]byte, error) {
 	}
 	return dec, nil
 }
+
+// DecodeToBytesStrict decodes a Base64 string using the proper encoding for JWTs,
+// rejecting non-canonical encodings, which have non-zero trailing bits, and any byte
+// outside the Base64url alphabet, including the newlines ignored by encoding/base64.
+func DecodeToBytesStrict(enc []byte) ([]byte, error) {
+	for i, c := range enc {
+		if !isBase64URL(c) {
+			return nil, base64.CorruptInputError(i)
+		}
+	}
+	encoding := base64.RawURLEncoding.Strict()
+	dec := make([]byte, encoding.DecodedLen(len(enc)))
+	n, err := encoding.Decode(dec, enc)
+	if err != nil {
+		return nil, err
+	}
+	return dec[:n], nil
+}
+
+func isBase64URL(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_'
+}
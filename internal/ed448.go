@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// Ed448PublicKeySize is the size, in bytes, of Ed448 public keys, as per the RFC 8032.
+	Ed448PublicKeySize = 57
+	// Ed448SignatureSize is the size, in bytes, of Ed448 signatures.
+	Ed448SignatureSize = 114
+)
+
+// ed448 holds the parameters of edwards448, x² + y² = 1 + d·x²·y².
+// Arithmetic uses math/big and projective coordinates, so it is neither
+// fast nor constant-time. That is why only verification is implemented:
+// it handles public values only, so timing leaks no secrets.
+var ed448 struct {
+	once sync.Once
+	p, d *big.Int
+	l    *big.Int
+	b    ed448Point
+	sqrt *big.Int // (p+1)/4, since p ≡ 3 (mod 4)
+}
+
+type ed448Point struct {
+	x, y, z *big.Int
+}
+
+func ed448Init() {
+	ed448.once.Do(func() {
+		one := big.NewInt(1)
+		p := new(big.Int).Lsh(one, 448)
+		p.Sub(p, new(big.Int).Lsh(one, 224))
+		p.Sub(p, one)
+		ed448.p = p
+		ed448.d = new(big.Int).Sub(p, big.NewInt(39081))
+		ed448.l, _ = new(big.Int).SetString("13818066809895115352007386748515426880336692474882178609894547503885", 10)
+		ed448.l.Sub(new(big.Int).Lsh(one, 446), ed448.l)
+		gx, _ := new(big.Int).SetString("224580040295924300187604334099896036246789641632564134246125461686950415467406032909029192869357953282578032075146446173674602635247710", 10)
+		gy, _ := new(big.Int).SetString("298819210078481492676017930443930673437544040154080242095928241372331506189835876003536878655418784733982303233503462500531545062832660", 10)
+		ed448.b = ed448Point{gx, gy, big.NewInt(1)}
+		ed448.sqrt = new(big.Int).Add(p, one)
+		ed448.sqrt.Rsh(ed448.sqrt, 2)
+	})
+}
+
+// Ed448Verify reports whether sig is a valid Ed448 signature of msg by pub.
+func Ed448Verify(pub, msg, sig []byte) bool {
+	ed448Init()
+	if len(pub) != Ed448PublicKeySize || len(sig) != Ed448SignatureSize {
+		return false
+	}
+	a, ok := ed448Decode(pub)
+	if !ok {
+		return false
+	}
+	r, ok := ed448Decode(sig[:Ed448SignatureSize/2])
+	if !ok {
+		return false
+	}
+	s := fromLittleEndian(sig[Ed448SignatureSize/2:])
+	if s.Cmp(ed448.l) >= 0 {
+		return false
+	}
+	k := ed448Hash(sig[:Ed448SignatureSize/2], pub, msg)
+
+	// Check the cofactored equation [4][S]B = [4]R + [4][k]A.
+	lhs := ed448Mul(ed448.b, s)
+	rhs := ed448Add(r, ed448Mul(a, k))
+	for i := 0; i < 2; i++ {
+		lhs = ed448Add(lhs, lhs)
+		rhs = ed448Add(rhs, rhs)
+	}
+	return subtle.ConstantTimeCompare(ed448Encode(lhs), ed448Encode(rhs)) == 1
+}
+
+// ed448Hash returns SHAKE256(dom4(0, "") || parts...) reduced modulo the group order.
+func ed448Hash(parts ...[]byte) *big.Int {
+	sh := sha3.NewShake256()
+	sh.Write([]byte("SigEd448\x00\x00"))
+	for _, p := range parts {
+		sh.Write(p)
+	}
+	h := make([]byte, Ed448SignatureSize)
+	sh.Read(h)
+	n := fromLittleEndian(h)
+	return n.Mod(n, ed448.l)
+}
+
+func ed448Add(p, q ed448Point) ed448Point {
+	m := ed448.p
+	a := new(big.Int).Mul(p.z, q.z)
+	a.Mod(a, m)
+	b := new(big.Int).Mul(a, a)
+	c := new(big.Int).Mul(p.x, q.x)
+	c.Mod(c, m)
+	d := new(big.Int).Mul(p.y, q.y)
+	d.Mod(d, m)
+	e := new(big.Int).Mul(c, d)
+	e.Mul(e, ed448.d)
+	f := new(big.Int).Sub(b, e)
+	f.Mod(f, m)
+	g := new(big.Int).Add(b, e)
+	g.Mod(g, m)
+	h := new(big.Int).Add(p.x, p.y)
+	h.Mul(h, new(big.Int).Add(q.x, q.y))
+
+	x3 := h.Sub(h, c)
+	x3.Sub(x3, d)
+	x3.Mul(x3, f)
+	x3.Mul(x3, a)
+	x3.Mod(x3, m)
+	y3 := d.Sub(d, c)
+	y3.Mul(y3, g)
+	y3.Mul(y3, a)
+	y3.Mod(y3, m)
+	z3 := f.Mul(f, g)
+	z3.Mod(z3, m)
+	return ed448Point{x3, y3, z3}
+}
+
+func ed448Mul(p ed448Point, k *big.Int) ed448Point {
+	q := ed448Point{new(big.Int), big.NewInt(1), big.NewInt(1)}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		q = ed448Add(q, q)
+		if k.Bit(i) == 1 {
+			q = ed448Add(q, p)
+		}
+	}
+	return q
+}
+
+func ed448Encode(p ed448Point) []byte {
+	zinv := new(big.Int).ModInverse(p.z, ed448.p)
+	x := new(big.Int).Mul(p.x, zinv)
+	x.Mod(x, ed448.p)
+	y := zinv.Mul(p.y, zinv)
+	y.Mod(y, ed448.p)
+	b := littleEndian(y, Ed448PublicKeySize)
+	b[Ed448PublicKeySize-1] |= byte(x.Bit(0) << 7)
+	return b
+}
+
+func ed448Decode(b []byte) (ed448Point, bool) {
+	m := ed448.p
+	enc := make([]byte, len(b))
+	copy(enc, b)
+	sign := uint(enc[len(enc)-1] >> 7)
+	enc[len(enc)-1] &= 0x7f
+	y := fromLittleEndian(enc)
+	if y.Cmp(m) >= 0 {
+		return ed448Point{}, false
+	}
+	// x² = (y² - 1) / (d·y² - 1)
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, m)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	v := y2.Mul(y2, ed448.d)
+	v.Sub(v, big.NewInt(1))
+	v.Mod(v, m)
+	vinv := v.ModInverse(v, m)
+	if vinv == nil {
+		return ed448Point{}, false
+	}
+	x2 := u.Mul(u, vinv)
+	x2.Mod(x2, m)
+	x := new(big.Int).Exp(x2, ed448.sqrt, m)
+	if new(big.Int).Mod(new(big.Int).Mul(x, x), m).Cmp(x2) != 0 {
+		return ed448Point{}, false
+	}
+	if x.Sign() == 0 && sign == 1 {
+		return ed448Point{}, false
+	}
+	if x.Bit(0) != sign {
+		x.Sub(m, x)
+	}
+	return ed448Point{x, y, big.NewInt(1)}, true
+}
+
+func littleEndian(n *big.Int, size int) []byte {
+	b := make([]byte, size)
+	nb := n.Bytes()
+	for i := range nb {
+		b[i] = nb[len(nb)-1-i]
+	}
+	return b
+}
+
+func fromLittleEndian(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[i] = b[len(b)-1-i]
+	}
+	return new(big.Int).SetBytes(be)
+}
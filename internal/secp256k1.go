@@ -0,0 +1,194 @@
+package internal
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+)
+
+// secp256k1 implements elliptic.Curve for the curve y² = x³ + 7 from the SEC 2.
+// Unlike elliptic.CurveParams, whose arithmetic assumes a = -3, it uses
+// Jacobian coordinates with a = 0.
+type secp256k1 struct {
+	*elliptic.CurveParams
+}
+
+var (
+	secp256k1Once  sync.Once
+	secp256k1Curve secp256k1
+)
+
+// Secp256k1 returns the secp256k1 curve, as used by the ES256K algorithm.
+func Secp256k1() elliptic.Curve {
+	secp256k1Once.Do(func() {
+		params := &elliptic.CurveParams{Name: "secp256k1", BitSize: 256}
+		params.P, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+		params.N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+		params.B = big.NewInt(7)
+		params.Gx, _ = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+		params.Gy, _ = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+		secp256k1Curve = secp256k1{params}
+	})
+	return secp256k1Curve
+}
+
+// Params returns the curve's parameters.
+func (c secp256k1) Params() *elliptic.CurveParams {
+	return c.CurveParams
+}
+
+// IsOnCurve reports whether (x, y) satisfies y² = x³ + 7.
+func (c secp256k1) IsOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(c.P) >= 0 || y.Sign() < 0 || y.Cmp(c.P) >= 0 {
+		return false
+	}
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, c.P)
+	x3 := new(big.Int).Mul(x, x)
+	x3.Mul(x3, x)
+	x3.Add(x3, c.B)
+	x3.Mod(x3, c.P)
+	return x3.Cmp(y2) == 0
+}
+
+// Add returns the sum of (x1, y1) and (x2, y2).
+func (c secp256k1) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	return c.affine(c.add(c.jacobian(x1, y1), c.jacobian(x2, y2)))
+}
+
+// Double returns 2*(x, y).
+func (c secp256k1) Double(x, y *big.Int) (*big.Int, *big.Int) {
+	return c.affine(c.double(c.jacobian(x, y)))
+}
+
+// ScalarMult returns k*(x, y), where k is a big-endian integer.
+func (c secp256k1) ScalarMult(x, y *big.Int, k []byte) (*big.Int, *big.Int) {
+	p := c.jacobian(x, y)
+	q := jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	for _, b := range k {
+		for i := 7; i >= 0; i-- {
+			q = c.double(q)
+			if b>>uint(i)&1 == 1 {
+				q = c.add(q, p)
+			}
+		}
+	}
+	return c.affine(q)
+}
+
+// ScalarBaseMult returns k*G, where G is the base point and k is a big-endian integer.
+func (c secp256k1) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.ScalarMult(c.Gx, c.Gy, k)
+}
+
+type jacobianPoint struct {
+	x, y, z *big.Int
+}
+
+func (c secp256k1) jacobian(x, y *big.Int) jacobianPoint {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	return jacobianPoint{new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)}
+}
+
+func (c secp256k1) affine(p jacobianPoint) (*big.Int, *big.Int) {
+	if p.z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+	zinv := new(big.Int).ModInverse(p.z, c.P)
+	zinv2 := new(big.Int).Mul(zinv, zinv)
+	x := new(big.Int).Mul(p.x, zinv2)
+	x.Mod(x, c.P)
+	y := zinv2.Mul(zinv2, zinv)
+	y.Mul(y, p.y)
+	y.Mod(y, c.P)
+	return x, y
+}
+
+// double uses the "dbl-2009-l" formulas for a = 0.
+func (c secp256k1) double(p jacobianPoint) jacobianPoint {
+	if p.z.Sign() == 0 || p.y.Sign() == 0 {
+		return jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	a := new(big.Int).Mul(p.x, p.x)
+	b := new(big.Int).Mul(p.y, p.y)
+	b.Mod(b, c.P)
+	cc := new(big.Int).Mul(b, b)
+	d := new(big.Int).Add(p.x, b)
+	d.Mul(d, d)
+	d.Sub(d, a)
+	d.Sub(d, cc)
+	d.Lsh(d, 1)
+	d.Mod(d, c.P)
+	e := a.Mul(a, big.NewInt(3))
+	e.Mod(e, c.P)
+	f := new(big.Int).Mul(e, e)
+
+	x3 := f.Sub(f, new(big.Int).Lsh(d, 1))
+	x3.Mod(x3, c.P)
+	y3 := d.Sub(d, x3)
+	y3.Mul(y3, e)
+	y3.Sub(y3, cc.Lsh(cc, 3))
+	y3.Mod(y3, c.P)
+	z3 := new(big.Int).Mul(p.y, p.z)
+	z3.Lsh(z3, 1)
+	z3.Mod(z3, c.P)
+	return jacobianPoint{x3, y3, z3}
+}
+
+// add uses the "add-2007-bl" formulas.
+func (c secp256k1) add(p, q jacobianPoint) jacobianPoint {
+	if p.z.Sign() == 0 {
+		return q
+	}
+	if q.z.Sign() == 0 {
+		return p
+	}
+	z1z1 := new(big.Int).Mul(p.z, p.z)
+	z1z1.Mod(z1z1, c.P)
+	z2z2 := new(big.Int).Mul(q.z, q.z)
+	z2z2.Mod(z2z2, c.P)
+	u1 := new(big.Int).Mul(p.x, z2z2)
+	u1.Mod(u1, c.P)
+	u2 := new(big.Int).Mul(q.x, z1z1)
+	u2.Mod(u2, c.P)
+	s1 := new(big.Int).Mul(p.y, q.z)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, c.P)
+	s2 := new(big.Int).Mul(q.y, p.z)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, c.P)
+
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, c.P)
+	r := new(big.Int).Sub(s2, s1)
+	r.Mod(r, c.P)
+	if h.Sign() == 0 {
+		if r.Sign() == 0 {
+			return c.double(p)
+		}
+		return jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	r.Lsh(r, 1)
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	j := new(big.Int).Mul(h, i)
+	v := u1.Mul(u1, i)
+
+	x3 := new(big.Int).Mul(r, r)
+	x3.Sub(x3, j)
+	x3.Sub(x3, new(big.Int).Lsh(v, 1))
+	x3.Mod(x3, c.P)
+	y3 := v.Sub(v, x3)
+	y3.Mul(y3, r)
+	y3.Sub(y3, s1.Mul(s1, j).Lsh(s1, 1))
+	y3.Mod(y3, c.P)
+	z3 := new(big.Int).Add(p.z, q.z)
+	z3.Mul(z3, z3)
+	z3.Sub(z3, z1z1)
+	z3.Sub(z3, z2z2)
+	z3.Mul(z3, h)
+	z3.Mod(z3, c.P)
+	return jacobianPoint{x3, y3, z3}
+}
@@ -2,7 +2,12 @@ package jwt
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
 )
 
 // ErrNotJSONObject is the error for when a JWT payload is not a JSON object.
@@ -10,5 +15,124 @@ var ErrNotJSONObject = errors.New("jwt: payload is not a valid JSON object")
 
 func isJSONObject(payload []byte) bool {
 	payload = bytes.TrimSpace(payload)
+	if len(payload) < 2 {
+		return false
+	}
 	return payload[0] == '{' && payload[len(payload)-1] == '}'
 }
+
+// ErrDuplicateMember is the error for when a JSON object contains the same member more than once.
+var ErrDuplicateMember = internal.NewError("jwt: duplicate JSON member")
+
+// maxJSONDepth is the same nesting limit enforced by encoding/json.
+const maxJSONDepth = 10000
+
+// checkDuplicateMembers checks whether any object in b contains duplicate members,
+// comparing them case-insensitively, as encoding/json does when decoding into structs.
+// Names are folded the same way encoding/json folds them, so non-ASCII letters such
+// as "ſ" (U+017F) and "K" (U+212A) match "s" and "k".
+func checkDuplicateMembers(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return checkJSONValue(dec, 0)
+}
+
+// foldName maps each rune of name to the smallest rune equivalent
+// to it under Unicode simple case folding, as encoding/json does.
+func foldName(name string) string {
+	return strings.Map(func(r rune) rune {
+		for {
+			r2 := unicode.SimpleFold(r)
+			if r2 <= r {
+				return r2
+			}
+			r = r2
+		}
+	}, name)
+}
+
+func checkJSONValue(dec *json.Decoder, depth int) error {
+	if depth > maxJSONDepth {
+		return internal.Errorf("jwt: JSON nesting exceeds %d levels: %w", maxJSONDepth, ErrMalformed)
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		seen := make(map[string]struct{})
+		for dec.More() {
+			if tok, err = dec.Token(); err != nil {
+				return err
+			}
+			name, _ := tok.(string)
+			key := foldName(name)
+			if _, ok := seen[key]; ok {
+				return internal.Errorf("jwt: %q: %w", name, ErrDuplicateMember)
+			}
+			seen[key] = struct{}{}
+			if err = checkJSONValue(dec, depth+1); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		for dec.More() {
+			if err = checkJSONValue(dec, depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	_, err = dec.Token() // closing delimiter
+	return err
+}
+
+// replaceMembers returns a copy of the JSON object b in which the value of each member
+// is replaced by the one returned by replace, if any, keeping the order of members.
+// If no member is replaced, b is returned as is.
+func replaceMembers(b []byte, replace func(name string, v json.RawMessage) (json.RawMessage, error)) ([]byte, error) {
+	var (
+		dec      = json.NewDecoder(bytes.NewReader(b))
+		buf      bytes.Buffer
+		replaced bool
+	)
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := tok.(string)
+		var v json.RawMessage
+		if err = dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		nv, err := replace(name, v)
+		if err != nil {
+			return nil, err
+		}
+		if nv != nil {
+			v, replaced = nv, true
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		nb, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(nb)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	if !replaced {
+		return b, nil
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,290 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	// ErrJWKUnsupported is the error for a JWK whose key type or curve is not supported.
+	ErrJWKUnsupported = internal.NewError("jwt: JWK type or curve is not supported")
+	// ErrJWKInvalid is the error for a JWK with missing or malformed parameters.
+	ErrJWKInvalid = internal.NewError("jwt: JWK is invalid")
+)
+
+// JWK is a JSON Web Key, as per the RFC 7517.
+// Binary parameters are Base64URL encoded without padding.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	D         string `json:"d,omitempty"`
+	P         string `json:"p,omitempty"`
+	Q         string `json:"q,omitempty"`
+	DP        string `json:"dp,omitempty"`
+	DQ        string `json:"dq,omitempty"`
+	QI        string `json:"qi,omitempty"`
+	K         string `json:"k,omitempty"`
+}
+
+// JWKSet is a JWK Set, as per the RFC 7517.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var jwkCurves = map[string]func() elliptic.Curve{
+	"P-256":     elliptic.P256,
+	"P-384":     elliptic.P384,
+	"P-521":     elliptic.P521,
+	"secp256k1": internal.Secp256k1,
+}
+
+// ECDSAJWK creates a JWK from an ECDSA key, which may either be
+// an *ecdsa.PrivateKey or an *ecdsa.PublicKey. Private keys also set "d".
+func ECDSAJWK(key interface{}) (*JWK, error) {
+	var (
+		pub  *ecdsa.PublicKey
+		priv *ecdsa.PrivateKey
+	)
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		priv, pub = k, &k.PublicKey
+	case *ecdsa.PublicKey:
+		pub = k
+	default:
+		return nil, internal.Errorf("jwt: %T: %w", key, ErrKeyType)
+	}
+	if pub == nil || pub.Curve == nil {
+		return nil, ErrECDSANilPubKey
+	}
+	params := pub.Params()
+	if _, ok := jwkCurves[params.Name]; !ok {
+		return nil, internal.Errorf("jwt: curve %q: %w", params.Name, ErrJWKUnsupported)
+	}
+	size := byteSize(params.BitSize)
+	jwk := JWK{
+		KeyType: "EC",
+		Curve:   params.Name,
+		X:       encodeJWKInt(pub.X, size),
+		Y:       encodeJWKInt(pub.Y, size),
+	}
+	if priv != nil {
+		jwk.D = encodeJWKInt(priv.D, byteSize(params.N.BitLen()))
+	}
+	return &jwk, nil
+}
+
+// NewJWK creates a JWK from key, which may be a []byte for HMAC, an RSA, ECDSA or Ed25519 key
+// or an Ed448 public key.
+// Unlike PublicJWK, private keys also set their private parameters, so the JWK must be kept secret.
+func NewJWK(key interface{}) (*JWK, error) {
+	enc := base64.RawURLEncoding.EncodeToString
+	switch k := key.(type) {
+	case []byte:
+		return &JWK{KeyType: "oct", K: enc(k)}, nil
+	case *rsa.PrivateKey:
+		if len(k.Primes) != 2 {
+			return nil, internal.Errorf("jwt: RSA key with %d primes: %w", len(k.Primes), ErrJWKUnsupported)
+		}
+		jwk, err := PublicJWK(&k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		// Compute the CRT values instead of relying on Precompute having been called.
+		one := big.NewInt(1)
+		p, q := k.Primes[0], k.Primes[1]
+		dp := new(big.Int).Mod(k.D, new(big.Int).Sub(p, one))
+		dq := new(big.Int).Mod(k.D, new(big.Int).Sub(q, one))
+		qi := new(big.Int).ModInverse(q, p)
+		jwk.D, jwk.P, jwk.Q = enc(k.D.Bytes()), enc(p.Bytes()), enc(q.Bytes())
+		jwk.DP, jwk.DQ, jwk.QI = enc(dp.Bytes()), enc(dq.Bytes()), enc(qi.Bytes())
+		return jwk, nil
+	case *ecdsa.PrivateKey:
+		return ECDSAJWK(k)
+	case ed25519.PrivateKey:
+		jwk, err := PublicJWK(k)
+		if err != nil {
+			return nil, err
+		}
+		jwk.D = enc(k.Seed())
+		return jwk, nil
+	}
+	return PublicJWK(key)
+}
+
+// PublicJWK creates a JWK holding only the public part of key, which may be an RSA, ECDSA
+// or Ed25519 key, either public or private, or an Ed448 public key. Private parameters are never set, so
+// the JWK is always safe to publish.
+func PublicJWK(key interface{}) (*JWK, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return PublicJWK(&k.PublicKey)
+	case *rsa.PublicKey:
+		if k == nil || k.N == nil {
+			return nil, ErrRSANilPubKey
+		}
+		return &JWK{
+			KeyType: "RSA",
+			N:       base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *ecdsa.PrivateKey:
+		return ECDSAJWK(&k.PublicKey)
+	case *ecdsa.PublicKey:
+		return ECDSAJWK(k)
+	case ed25519.PrivateKey:
+		if len(k) != ed25519.PrivateKeySize {
+			return nil, ErrEd25519KeySize
+		}
+		return PublicJWK(k.Public())
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, ErrEd25519KeySize
+		}
+		return &JWK{KeyType: "OKP", Curve: "Ed25519", X: base64.RawURLEncoding.EncodeToString(k)}, nil
+	case Ed448PublicKey:
+		if len(k) != internal.Ed448PublicKeySize {
+			return nil, ErrEd448KeySize
+		}
+		return &JWK{KeyType: "OKP", Curve: "Ed448", X: base64.RawURLEncoding.EncodeToString(k)}, nil
+	}
+	return nil, internal.Errorf("jwt: %T: %w", key, ErrJWKUnsupported)
+}
+
+// RSAPublicKey returns the public key contained in an "RSA" JWK.
+func (k *JWK) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, internal.Errorf("jwt: key type %q: %w", k.KeyType, ErrJWKUnsupported)
+	}
+	n, err := decodeJWKUint("n", k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeJWKUint("e", k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 || e.Bit(0) == 0 {
+		return nil, internal.Errorf("jwt: \"e\" is out of range: %w", ErrJWKInvalid)
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// EdDSAPublicKey returns the public key contained in an "OKP" JWK, which is
+// either an ed25519.PublicKey or an Ed448PublicKey, depending on its curve.
+func (k *JWK) EdDSAPublicKey() (crypto.PublicKey, error) {
+	if k.KeyType != "OKP" {
+		return nil, internal.Errorf("jwt: key type %q: %w", k.KeyType, ErrJWKUnsupported)
+	}
+	var size int
+	switch k.Curve {
+	case "Ed25519":
+		size = ed25519.PublicKeySize
+	case "Ed448":
+		size = internal.Ed448PublicKeySize
+	default:
+		return nil, internal.Errorf("jwt: curve %q: %w", k.Curve, ErrJWKUnsupported)
+	}
+	x, err := internal.DecodeToBytesStrict([]byte(k.X))
+	if err != nil {
+		return nil, internal.Errorf("jwt: \"x\": %v: %w", err, ErrJWKInvalid)
+	}
+	if len(x) != size {
+		return nil, internal.Errorf("jwt: \"x\" has %d bytes, want %d: %w", len(x), size, ErrJWKInvalid)
+	}
+	if k.Curve == "Ed448" {
+		return Ed448PublicKey(x), nil
+	}
+	return ed25519.PublicKey(x), nil
+}
+
+// ECDSAPublicKey returns the public key contained in an "EC" JWK.
+func (k *JWK) ECDSAPublicKey() (*ecdsa.PublicKey, error) {
+	if k.KeyType != "EC" {
+		return nil, internal.Errorf("jwt: key type %q: %w", k.KeyType, ErrJWKUnsupported)
+	}
+	crv, ok := jwkCurves[k.Curve]
+	if !ok {
+		return nil, internal.Errorf("jwt: curve %q: %w", k.Curve, ErrJWKUnsupported)
+	}
+	c := crv()
+	size := byteSize(c.Params().BitSize)
+	x, err := decodeJWKInt("x", k.X, size)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeJWKInt("y", k.Y, size)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsOnCurve(x, y) {
+		return nil, internal.Errorf("jwt: point is not on curve %q: %w", k.Curve, ErrJWKInvalid)
+	}
+	return &ecdsa.PublicKey{Curve: c, X: x, Y: y}, nil
+}
+
+// ECDSAPrivateKey returns the private key contained in an "EC" JWK.
+func (k *JWK) ECDSAPrivateKey() (*ecdsa.PrivateKey, error) {
+	pub, err := k.ECDSAPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if k.Curve == "secp256k1" {
+		return nil, internal.Errorf("jwt: curve %q: %w", k.Curve, ErrES256KPrivateKey)
+	}
+	d, err := decodeJWKInt("d", k.D, byteSize(pub.Params().N.BitLen()))
+	if err != nil {
+		return nil, err
+	}
+	if d.Sign() <= 0 || d.Cmp(pub.Params().N) >= 0 {
+		return nil, internal.Errorf("jwt: \"d\" is out of range: %w", ErrJWKInvalid)
+	}
+	if x, y := pub.ScalarBaseMult(d.Bytes()); x.Cmp(pub.X) != 0 || y.Cmp(pub.Y) != 0 {
+		return nil, internal.Errorf("jwt: \"d\" doesn't match public key: %w", ErrJWKInvalid)
+	}
+	return &ecdsa.PrivateKey{PublicKey: *pub, D: d}, nil
+}
+
+func encodeJWKInt(n *big.Int, size int) string {
+	b := make([]byte, size)
+	nb := n.Bytes()
+	copy(b[size-len(nb):], nb)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeJWKInt decodes a fixed-size big-endian integer, as required for "x", "y" and "d" by the RFC 7518.
+func decodeJWKInt(name, enc string, size int) (*big.Int, error) {
+	b, err := internal.DecodeToBytesStrict([]byte(enc))
+	if err != nil {
+		return nil, internal.Errorf("jwt: %q: %v: %w", name, err, ErrJWKInvalid)
+	}
+	if len(b) != size {
+		return nil, internal.Errorf("jwt: %q has %d bytes, want %d: %w", name, len(b), size, ErrJWKInvalid)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// decodeJWKUint decodes a minimal big-endian unsigned integer, as required for "n" and "e" by the RFC 7518.
+func decodeJWKUint(name, enc string) (*big.Int, error) {
+	b, err := internal.DecodeToBytesStrict([]byte(enc))
+	if err != nil {
+		return nil, internal.Errorf("jwt: %q: %v: %w", name, err, ErrJWKInvalid)
+	}
+	if len(b) == 0 || b[0] == 0 {
+		return nil, internal.Errorf("jwt: %q is not minimally encoded: %w", name, ErrJWKInvalid)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
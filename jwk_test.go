@@ -0,0 +1,295 @@
+package jwt_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestECDSAJWK(t *testing.T) {
+	testCases := []struct {
+		crv  string
+		priv *ecdsa.PrivateKey
+	}{
+		{"P-256", es256PrivateKey1},
+		{"P-384", es384PrivateKey1},
+		{"P-521", es512PrivateKey1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.crv, func(t *testing.T) {
+			jwk, err := jwt.ECDSAJWK(tc.priv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.crv, jwk.Curve; got != want {
+				t.Errorf("want %s, got %s", want, got)
+			}
+			b, err := json.Marshal(jwk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var dec jwt.JWK
+			if err = json.Unmarshal(b, &dec); err != nil {
+				t.Fatal(err)
+			}
+			priv, err := dec.ECDSAPrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if priv.D.Cmp(tc.priv.D) != 0 || priv.X.Cmp(tc.priv.X) != 0 || priv.Y.Cmp(tc.priv.Y) != 0 {
+				t.Errorf("jwt.JWK.ECDSAPrivateKey mismatch")
+			}
+			pubJWK, err := jwt.ECDSAJWK(&tc.priv.PublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pubJWK.D != "" {
+				t.Errorf("public JWK has \"d\" set")
+			}
+			if _, err = pubJWK.ECDSAPrivateKey(); !internal.ErrorIs(err, jwt.ErrJWKInvalid) {
+				t.Errorf("want %v, got %v", jwt.ErrJWKInvalid, err)
+			}
+		})
+	}
+}
+
+func TestJWKSecp256k1(t *testing.T) {
+	// The private key 1 maps to the curve's base point.
+	jwk := jwt.JWK{
+		KeyType: "EC",
+		Curve:   "secp256k1",
+		X:       "eb5mfvncu6xVoGKVzocLBwKb_NstzijZWfKBWxb4F5g",
+		Y:       "SDradyajxGVdpPv8DhEIqP0XtEimhVQZnEfQj_sQ1Lg",
+		D:       "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAE",
+	}
+	if _, err := jwk.ECDSAPrivateKey(); !internal.ErrorIs(err, jwt.ErrES256KPrivateKey) {
+		t.Errorf("want %v, got %v", jwt.ErrES256KPrivateKey, err)
+	}
+	pub, err := jwk.ECDSAPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, y := jwt.Secp256k1().Params().Gx, jwt.Secp256k1().Params().Gy; pub.X.Cmp(x) != 0 || pub.Y.Cmp(y) != 0 {
+		t.Errorf("jwt.JWK.ECDSAPublicKey mismatch")
+	}
+
+	exported, err := jwt.ECDSAJWK(es256kPublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub, err = exported.ECDSAPublicKey(); err != nil {
+		t.Fatal(err)
+	}
+	es256k, err := jwt.NewECDSASHA("ES256K", jwt.ECDSAPublicKey(pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Sign(jwt.Payload{Subject: "secp256k1"}, es256kSigner1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pl jwt.Payload
+	if _, err = jwt.Verify(token, es256k, &pl); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJWKInvalid(t *testing.T) {
+	valid, err := jwt.ECDSAJWK(es256PublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name string
+		mod  func(*jwt.JWK)
+		err  error
+	}{
+		{"kty", func(k *jwt.JWK) { k.KeyType = "RSA" }, jwt.ErrJWKUnsupported},
+		{"crv", func(k *jwt.JWK) { k.Curve = "P-192" }, jwt.ErrJWKUnsupported},
+		{"x", func(k *jwt.JWK) { k.X = k.X[:10] }, jwt.ErrJWKInvalid},
+		{"y", func(k *jwt.JWK) { k.Y = "!" }, jwt.ErrJWKInvalid},
+		{"point", func(k *jwt.JWK) { k.X, k.Y = k.Y, k.X }, jwt.ErrJWKInvalid},
+		{"wrong curve", func(k *jwt.JWK) { k.Curve = "secp256k1" }, jwt.ErrJWKInvalid},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jwk := *valid
+			tc.mod(&jwk)
+			_, err := jwk.ECDSAPublicKey()
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.JWK.ECDSAPublicKey err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestPublicJWK(t *testing.T) {
+	testCases := []struct {
+		name  string
+		key   interface{}
+		pub   crypto.PublicKey
+		parse func(*jwt.JWK) (crypto.PublicKey, error)
+	}{
+		{"RSA private", rsaPrivateKey1, rsaPublicKey1, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.RSAPublicKey() }},
+		{"RSA public", rsaPublicKey2, rsaPublicKey2, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.RSAPublicKey() }},
+		{"EC private", es256PrivateKey1, es256PublicKey1, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.ECDSAPublicKey() }},
+		{"EC public", es512PublicKey1, es512PublicKey1, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.ECDSAPublicKey() }},
+		{"Ed25519 private", ed25519PrivateKey1, ed25519PublicKey1, (*jwt.JWK).EdDSAPublicKey},
+		{"Ed25519 public", ed25519PublicKey2, ed25519PublicKey2, (*jwt.JWK).EdDSAPublicKey},
+		{"Ed448 public", ed448PublicKey2, ed448PublicKey2, (*jwt.JWK).EdDSAPublicKey},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jwk, err := jwt.PublicJWK(tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if jwk.D != "" {
+				t.Errorf("private parameter %q leaked", jwk.D)
+			}
+			b, err := json.Marshal(jwk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var dec jwt.JWK
+			if err = json.Unmarshal(b, &dec); err != nil {
+				t.Fatal(err)
+			}
+			pub, err := tc.parse(&dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := jwt.PublicJWK(tc.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := jwt.PublicJWK(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("jwt.PublicJWK mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if _, err := jwt.PublicJWK([]byte("secret")); !internal.ErrorIs(err, jwt.ErrJWKUnsupported) {
+		t.Errorf("want %v, got %v", jwt.ErrJWKUnsupported, err)
+	}
+}
+
+func TestJWKInvalidRSAOKP(t *testing.T) {
+	rsaJWK, err := jwt.PublicJWK(rsaPublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	okpJWK, err := jwt.PublicJWK(ed25519PublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name  string
+		jwk   *jwt.JWK
+		mod   func(*jwt.JWK)
+		parse func(*jwt.JWK) (crypto.PublicKey, error)
+		err   error
+	}{
+		{"RSA kty", rsaJWK, func(k *jwt.JWK) { k.KeyType = "EC" }, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.RSAPublicKey() }, jwt.ErrJWKUnsupported},
+		{"RSA n", rsaJWK, func(k *jwt.JWK) { k.N = "AA" + k.N }, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.RSAPublicKey() }, jwt.ErrJWKInvalid},
+		{"RSA e", rsaJWK, func(k *jwt.JWK) { k.E = "Ag" }, func(k *jwt.JWK) (crypto.PublicKey, error) { return k.RSAPublicKey() }, jwt.ErrJWKInvalid},
+		{"OKP kty", okpJWK, func(k *jwt.JWK) { k.KeyType = "RSA" }, (*jwt.JWK).EdDSAPublicKey, jwt.ErrJWKUnsupported},
+		{"OKP crv", okpJWK, func(k *jwt.JWK) { k.Curve = "X25519" }, (*jwt.JWK).EdDSAPublicKey, jwt.ErrJWKUnsupported},
+		{"OKP x", okpJWK, func(k *jwt.JWK) { k.Curve = "Ed448" }, (*jwt.JWK).EdDSAPublicKey, jwt.ErrJWKInvalid},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jwk := *tc.jwk
+			tc.mod(&jwk)
+			_, err := tc.parse(&jwk)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.JWK err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestNewJWK(t *testing.T) {
+	dec := func(s string) *big.Int {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return new(big.Int).SetBytes(b)
+	}
+
+	rsaJWK, err := jwt.NewJWK(rsaPrivateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv := *rsaPrivateKey1
+	priv.Precompute()
+	for name, tc := range map[string]struct {
+		want *big.Int
+		got  string
+	}{
+		"d":  {priv.D, rsaJWK.D},
+		"p":  {priv.Primes[0], rsaJWK.P},
+		"q":  {priv.Primes[1], rsaJWK.Q},
+		"dp": {priv.Precomputed.Dp, rsaJWK.DP},
+		"dq": {priv.Precomputed.Dq, rsaJWK.DQ},
+		"qi": {priv.Precomputed.Qinv, rsaJWK.QI},
+	} {
+		if got := dec(tc.got); got.Cmp(tc.want) != 0 {
+			t.Errorf("%s: want %v, got %v", name, tc.want, got)
+		}
+	}
+
+	ecJWK, err := jwt.NewJWK(es384PrivateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecJWK.ECDSAPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := es384PrivateKey1.D, ecPriv.D; got.Cmp(want) != 0 {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	testCases := []struct {
+		name string
+		key  interface{}
+		want jwt.JWK
+	}{
+		{"oct", []byte("secret"), jwt.JWK{KeyType: "oct", K: "c2VjcmV0"}},
+		{"Ed25519", ed25519PrivateKey1, jwt.JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       base64.RawURLEncoding.EncodeToString(ed25519PublicKey1),
+			D:       base64.RawURLEncoding.EncodeToString(ed25519PrivateKey1.Seed()),
+		}},
+		{"Ed448", ed448PublicKey1, jwt.JWK{
+			KeyType: "OKP",
+			Curve:   "Ed448",
+			X:       base64.RawURLEncoding.EncodeToString(ed448PublicKey1),
+		}},
+		{"public", rsaPublicKey1, jwt.JWK{KeyType: "RSA", N: rsaJWK.N, E: rsaJWK.E}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jwt.NewJWK(tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(&tc.want, got); diff != "" {
+				t.Errorf("jwt.NewJWK mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package jwtutil
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// JWKSHandler is an http.Handler that serves the public keys of a set of keys as a JWK Set.
+// Only the public part of keys is ever served, and keys whose algorithm has no public key,
+// such as HMAC, and retired keys are skipped.
+//
+// Responses carry an ETag computed from their content and a Cache-Control header, and
+// conditional GET requests using If-None-Match are answered with 304 Not Modified.
+type JWKSHandler struct {
+	// Keys returns the keys to publish, such as a KeyRing's Keys method.
+	Keys func() []Key
+	// MaxAge is for how long clients may cache the JWK Set. Zero disables caching.
+	MaxAge time.Duration
+}
+
+var _ http.Handler = new(JWKSHandler)
+
+// JWKSet returns the JWK Set served by the handler.
+func (h *JWKSHandler) JWKSet() (*jwt.JWKSet, error) {
+	set := jwt.JWKSet{Keys: []jwt.JWK{}}
+	for _, k := range h.Keys() {
+		if k.Status == KeyRetired {
+			continue
+		}
+		alg, ok := k.Algorithm.(jwt.PublicKeyAlgorithm)
+		if !ok {
+			continue
+		}
+		jwk, err := jwt.PublicJWK(alg.Public())
+		if err != nil {
+			return nil, err
+		}
+		jwk.KeyID = k.ID
+		jwk.Use = "sig"
+		jwk.Algorithm = jwkAlgorithm(alg.Name())
+		set.Keys = append(set.Keys, *jwk)
+	}
+	return &set, nil
+}
+
+// jwkAlgorithm returns the name registered for the algorithm named name, which is "EdDSA"
+// for Ed25519, as per the RFC 8037, since clients don't recognize "Ed25519" as a JWS algorithm.
+func jwkAlgorithm(name string) string {
+	if name == "Ed25519" {
+		return "EdDSA"
+	}
+	return name
+}
+
+// ServeHTTP serves the JWK Set for GET and HEAD requests.
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	set, err := h.JWKSet()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	body, err := json.Marshal(set)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+
+	hd := w.Header()
+	hd.Set("ETag", etag)
+	if maxAge := int64(h.MaxAge / time.Second); maxAge > 0 {
+		hd.Set("Cache-Control", "public, max-age="+strconv.FormatInt(maxAge, 10))
+	} else {
+		hd.Set("Cache-Control", "no-cache")
+	}
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	hd.Set("Content-Type", "application/jwk-set+json")
+	hd.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// etagMatch reports whether an If-None-Match header matches etag, using the weak comparison
+// required by the RFC 7232.
+func etagMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package jwtutil_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPriv, _ := internal.GenerateEd25519Keys()
+	kr, err := jwtutil.NewKeyRing(
+		jwtutil.Key{ID: "es256", Algorithm: jwt.NewES256(jwt.ECDSAPrivateKey(priv))},
+		jwtutil.Key{ID: "eddsa", Algorithm: jwt.NewEdDSA(jwt.EdDSAPrivateKey(edPriv)), Status: jwtutil.KeyVerifyOnly},
+		jwtutil.Key{ID: "ed25519", Algorithm: jwt.NewEd25519(jwt.Ed25519PrivateKey(edPriv)), Status: jwtutil.KeyVerifyOnly},
+		jwtutil.Key{ID: "hs256", Algorithm: hs256},
+		jwtutil.Key{ID: "retired", Algorithm: jwt.NewES256(jwt.ECDSAPrivateKey(priv)), Status: jwtutil.KeyRetired},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &jwtutil.JWKSHandler{Keys: kr.Keys, MaxAge: time.Hour}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	if want, got := http.StatusOK, rec.Code; got != want {
+		t.Fatalf("want %d, got %d", want, got)
+	}
+	for k, want := range map[string]string{
+		"Content-Type":  "application/jwk-set+json",
+		"Cache-Control": "public, max-age=3600",
+	} {
+		if got := rec.Header().Get(k); got != want {
+			t.Errorf("%s: want %q, got %q", k, want, got)
+		}
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+
+	var set jwt.JWKSet
+	if err = json.Unmarshal(rec.Body.Bytes(), &set); err != nil {
+		t.Fatal(err)
+	}
+	esJWK, err := jwt.PublicJWK(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	esJWK.KeyID, esJWK.Use, esJWK.Algorithm = "es256", "sig", "ES256"
+	edJWK, err := jwt.PublicJWK(edPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edJWK.KeyID, edJWK.Use, edJWK.Algorithm = "eddsa", "sig", "EdDSA"
+	// Keys for the Ed25519 algorithm are published under its registered name, "EdDSA".
+	ed25519JWK := *edJWK
+	ed25519JWK.KeyID = "ed25519"
+	want := jwt.JWKSet{Keys: []jwt.JWK{ed25519JWK, *edJWK, *esJWK}}
+	if diff := cmp.Diff(want, set); diff != "" {
+		t.Errorf("jwtutil.JWKSHandler mismatch (-want +got):\n%s", diff)
+	}
+	for _, k := range set.Keys {
+		if k.D != "" {
+			t.Errorf("%s: private key leaked", k.KeyID)
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantCode    int
+		wantBody    bool
+	}{
+		{"match", http.MethodGet, etag, http.StatusNotModified, false},
+		{"weak match", http.MethodGet, `"other", W/` + etag, http.StatusNotModified, false},
+		{"wildcard", http.MethodGet, "*", http.StatusNotModified, false},
+		{"no match", http.MethodGet, `"other"`, http.StatusOK, true},
+		{"head", http.MethodHead, "", http.StatusOK, false},
+		{"post", http.MethodPost, "", http.StatusMethodNotAllowed, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/.well-known/jwks.json", nil)
+			if tc.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+			if want, got := tc.wantCode, rec.Code; got != want {
+				t.Errorf("want %d, got %d", want, got)
+			}
+			if want, got := tc.wantBody, rec.Body.Len() > 0; got != want {
+				t.Errorf("want body %t, got %t", want, got)
+			}
+		})
+	}
+
+	// Rotating keys changes the ETag.
+	if err = kr.SetStatus("eddsa", jwtutil.KeyRetired); err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	r.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	if want, got := http.StatusOK, rec.Code; got != want {
+		t.Errorf("want %d, got %d", want, got)
+	}
+	if got := rec.Header().Get("ETag"); got == etag {
+		t.Errorf("ETag %s didn't change", got)
+	}
+}
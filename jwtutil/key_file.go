@@ -0,0 +1,275 @@
+package jwtutil
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"io/ioutil"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	// ErrKeyFormat is the error for data that doesn't contain a key in any supported encoding.
+	ErrKeyFormat = internal.NewError("jwtutil: unrecognized key encoding")
+	// ErrKeyEncrypted is the error for a key protected by a passphrase.
+	ErrKeyEncrypted = internal.NewError("jwtutil: key is encrypted")
+)
+
+// keyTypes maps algorithm names to the JWK key type they use.
+var keyTypes = map[string]string{
+	"HS256":   "oct",
+	"HS384":   "oct",
+	"HS512":   "oct",
+	"RS256":   "RSA",
+	"RS384":   "RSA",
+	"RS512":   "RSA",
+	"PS256":   "RSA",
+	"PS384":   "RSA",
+	"PS512":   "RSA",
+	"ES256":   "EC",
+	"ES384":   "EC",
+	"ES512":   "EC",
+	"ES256K":  "EC",
+	"EdDSA":   "OKP",
+	"Ed25519": "OKP",
+}
+
+// ecdsaCurveAlgs maps curves to the algorithm using them.
+var ecdsaCurveAlgs = map[string]string{
+	"P-256":     "ES256",
+	"P-384":     "ES384",
+	"P-521":     "ES512",
+	"secp256k1": "ES256K",
+}
+
+const openSSHMagic = "openssh-key-v1\x00"
+
+// LoadKey reads a key from the file at path. See ParseKey for supported encodings.
+func LoadKey(path string) (interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	k, err := ParseKey(b)
+	if err != nil {
+		return nil, internal.Errorf("jwtutil: %s: %w", path, err)
+	}
+	return k, nil
+}
+
+// ParseKey parses a private or public key, which may be PEM encoded or raw DER as PKCS #1, PKCS #8,
+// SEC 1, PKIX or an X.509 certificate, or be in the OpenSSH private key or authorized_keys format.
+//
+// The returned key is either an *rsa.PrivateKey, an *ecdsa.PrivateKey, an ed25519.PrivateKey,
+// an *rsa.PublicKey, an *ecdsa.PublicKey or an ed25519.PublicKey.
+func ParseKey(data []byte) (interface{}, error) {
+	rest := data
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Headers["Proc-Type"] == "4,ENCRYPTED" {
+			return nil, ErrKeyEncrypted
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return parseKey(x509.ParsePKCS1PrivateKey(block.Bytes))
+		case "RSA PUBLIC KEY":
+			return parseKey(x509.ParsePKCS1PublicKey(block.Bytes))
+		case "EC PRIVATE KEY":
+			return parseKey(x509.ParseECPrivateKey(block.Bytes))
+		case "PRIVATE KEY":
+			return parseKey(x509.ParsePKCS8PrivateKey(block.Bytes))
+		case "PUBLIC KEY":
+			return parseKey(x509.ParsePKIXPublicKey(block.Bytes))
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return parseKey(cert.PublicKey, nil)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, ErrKeyEncrypted
+		case "OPENSSH PRIVATE KEY":
+			if openSSHEncrypted(block.Bytes) {
+				return nil, ErrKeyEncrypted
+			}
+			return parseKey(ssh.ParseRawPrivateKey(pem.EncodeToMemory(block)))
+		}
+		// Skip other blocks, such as "EC PARAMETERS".
+	}
+	if pub, _, _, _, err := ssh.ParseAuthorizedKey(data); err == nil {
+		if cpk, ok := pub.(ssh.CryptoPublicKey); ok {
+			return parseKey(cpk.CryptoPublicKey(), nil)
+		}
+		return nil, internal.Errorf("jwtutil: SSH key type %q: %w", pub.Type(), ErrKeyFormat)
+	}
+	// Try DER, starting with the most common encodings.
+	if k, err := x509.ParsePKCS8PrivateKey(data); err == nil {
+		return parseKey(k, nil)
+	}
+	if k, err := x509.ParsePKIXPublicKey(data); err == nil {
+		return parseKey(k, nil)
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(data); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParseECPrivateKey(data); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParsePKCS1PublicKey(data); err == nil {
+		return k, nil
+	}
+	if cert, err := x509.ParseCertificate(data); err == nil {
+		return parseKey(cert.PublicKey, nil)
+	}
+	return nil, ErrKeyFormat
+}
+
+// parseKey normalizes keys returned by parsers and rejects unsupported key types, such as DSA keys.
+func parseKey(key interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey,
+		*rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return k, nil
+	case *ed25519.PrivateKey:
+		return *k, nil
+	}
+	return nil, internal.Errorf("jwtutil: %T: %w", key, jwt.ErrKeyType)
+}
+
+// openSSHEncrypted reports whether an OpenSSH private key uses a cipher.
+func openSSHEncrypted(b []byte) bool {
+	if !bytes.HasPrefix(b, []byte(openSSHMagic)) {
+		return false
+	}
+	b = b[len(openSSHMagic):]
+	if len(b) < 4 {
+		return false
+	}
+	n := binary.BigEndian.Uint32(b)
+	return uint64(n) <= uint64(len(b)-4) && string(b[4:4+n]) != "none"
+}
+
+// LoadAlgorithm reads a key from the file at path and creates an algorithm for it,
+// as LoadKey and NewAlgorithm do.
+func LoadAlgorithm(alg, path string) (jwt.Algorithm, error) {
+	k, err := LoadKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAlgorithm(alg, k)
+}
+
+// ParseAlgorithm parses a key and creates an algorithm for it, as ParseKey and NewAlgorithm do.
+func ParseAlgorithm(alg string, data []byte) (jwt.Algorithm, error) {
+	k, err := ParseKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewAlgorithm(alg, k)
+}
+
+// NewAlgorithm creates the algorithm named alg for key, which may be any key returned by ParseKey,
+// or a []byte for HMAC algorithms. Algorithms created for public keys can only verify.
+//
+// If alg is empty, it is inferred from key: "RS256" for RSA keys, the algorithm matching the curve
+// for ECDSA keys, "EdDSA" for Ed25519 and Ed448 keys and "HS256" for HMAC keys.
+// A key that doesn't match alg results in jwt.ErrKeyType. Since ES256K only verifies,
+// private keys on the secp256k1 curve result in jwt.ErrES256KPrivateKey.
+func NewAlgorithm(alg string, key interface{}) (jwt.Algorithm, error) {
+	kty, err := keyType(key)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := key.(*ecdsa.PrivateKey); ok && k.Curve != nil && k.Params().Name == "secp256k1" {
+		return nil, internal.Errorf("jwtutil: secp256k1 private key: %w", jwt.ErrES256KPrivateKey)
+	}
+	if alg == "" {
+		if alg, err = defaultAlgorithm(key); err != nil {
+			return nil, err
+		}
+	}
+	want, ok := keyTypes[alg]
+	if !ok {
+		return nil, internal.Errorf("jwtutil: %q: %w", alg, jwt.ErrAlgUnsupported)
+	}
+	if kty != want {
+		return nil, internal.Errorf("jwtutil: %T for %s: %w", key, alg, jwt.ErrKeyType)
+	}
+	switch k := key.(type) {
+	case []byte:
+		return jwt.NewHMACSHA(alg, k)
+	case *rsa.PrivateKey:
+		return jwt.NewRSASHA(alg, jwt.RSAPrivateKey(k))
+	case *rsa.PublicKey:
+		return jwt.NewRSASHA(alg, jwt.RSAPublicKey(k))
+	case *ecdsa.PrivateKey:
+		return jwt.NewECDSASHA(alg, jwt.ECDSAPrivateKey(k))
+	case *ecdsa.PublicKey:
+		return jwt.NewECDSASHA(alg, jwt.ECDSAPublicKey(k))
+	case ed25519.PrivateKey:
+		if alg == "Ed25519" {
+			return jwt.NewEd25519SHA(jwt.Ed25519PrivateKey(k))
+		}
+		return jwt.NewEdDSASHA(jwt.EdDSAPrivateKey(k))
+	case ed25519.PublicKey:
+		if alg == "Ed25519" {
+			return jwt.NewEd25519SHA(jwt.Ed25519PublicKey(k))
+		}
+		return jwt.NewEdDSASHA(jwt.EdDSAPublicKey(k))
+	case jwt.Ed448PublicKey:
+		if alg != "EdDSA" {
+			return nil, internal.Errorf("jwtutil: Ed448 key for %s: %w", alg, jwt.ErrKeyType)
+		}
+		return jwt.NewEdDSASHA(jwt.EdDSAPublicKey(k))
+	}
+	return nil, internal.Errorf("jwtutil: %T: %w", key, jwt.ErrKeyType)
+}
+
+// keyType returns the JWK key type of key.
+func keyType(key interface{}) (string, error) {
+	switch key.(type) {
+	case []byte:
+		return "oct", nil
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return "RSA", nil
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return "EC", nil
+	case ed25519.PrivateKey, ed25519.PublicKey, jwt.Ed448PublicKey:
+		return "OKP", nil
+	}
+	return "", internal.Errorf("jwtutil: %T: %w", key, jwt.ErrKeyType)
+}
+
+func defaultAlgorithm(key interface{}) (string, error) {
+	switch k := key.(type) {
+	case []byte:
+		return "HS256", nil
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return "RS256", nil
+	case *ecdsa.PrivateKey:
+		return defaultAlgorithm(&k.PublicKey)
+	case *ecdsa.PublicKey:
+		if k.Curve == nil {
+			return "", jwt.ErrECDSANilPubKey
+		}
+		alg, ok := ecdsaCurveAlgs[k.Params().Name]
+		if !ok {
+			return "", internal.Errorf("jwtutil: curve %q: %w", k.Params().Name, jwt.ErrAlgUnsupported)
+		}
+		return alg, nil
+	}
+	return "EdDSA", nil
+}
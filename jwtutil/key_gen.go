@@ -0,0 +1,94 @@
+package jwtutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/ed25519"
+)
+
+// ecdsaAlgCurves maps algorithm names to the curve they use.
+var ecdsaAlgCurves = map[string]func() elliptic.Curve{
+	"ES256": elliptic.P256,
+	"ES384": elliptic.P384,
+	"ES512": elliptic.P521,
+}
+
+// GenerateKey generates a new private key suitable for the algorithm named alg, using crypto/rand.
+//
+// The key is a []byte as long as the hash for "HS256", "HS384" and "HS512", an *rsa.PrivateKey
+// whose modulus has the 2048 bits required by jwt.RFC7518KeyPolicy for "RS*" and "PS*",
+// an *ecdsa.PrivateKey on the algorithm's curve for "ES256", "ES384" and "ES512",
+// and an ed25519.PrivateKey for "EdDSA" and "Ed25519". Since ES256K only verifies,
+// it results in jwt.ErrES256KPrivateKey.
+//
+// The key can be passed to NewAlgorithm, and exported with MarshalPEM and jwt.NewJWK.
+func GenerateKey(alg string) (interface{}, error) {
+	kty, ok := keyTypes[alg]
+	if !ok {
+		return nil, internal.Errorf("jwtutil: %q: %w", alg, jwt.ErrAlgUnsupported)
+	}
+	switch kty {
+	case "oct":
+		b := make([]byte, hmacKeySize(alg))
+		if _, err := io.ReadFull(rand.Reader, b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case "RSA":
+		return parseKey(rsa.GenerateKey(rand.Reader, jwt.RFC7518KeyPolicy().MinRSABits))
+	case "EC":
+		curve, ok := ecdsaAlgCurves[alg]
+		if !ok {
+			return nil, internal.Errorf("jwtutil: %q: %w", alg, jwt.ErrES256KPrivateKey)
+		}
+		return parseKey(ecdsa.GenerateKey(curve(), rand.Reader))
+	}
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return priv, nil
+}
+
+func hmacKeySize(alg string) int {
+	switch alg {
+	case "HS384":
+		return crypto.SHA384.Size()
+	case "HS512":
+		return crypto.SHA512.Size()
+	}
+	return crypto.SHA256.Size()
+}
+
+// MarshalPEM encodes key as PEM, which ParseKey reads back: private keys as a PKCS #8 "PRIVATE KEY"
+// block, and public keys as a PKIX "PUBLIC KEY" block. HMAC keys have no standard PEM encoding,
+// so use jwt.NewJWK for exporting them instead. Keys on the secp256k1 curve are not supported.
+func MarshalPEM(key interface{}) ([]byte, error) {
+	var (
+		block pem.Block
+		err   error
+	)
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		block.Type = "PRIVATE KEY"
+		block.Bytes, err = x509.MarshalPKCS8PrivateKey(k)
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		block.Type = "PUBLIC KEY"
+		block.Bytes, err = x509.MarshalPKIXPublicKey(k)
+	default:
+		return nil, internal.Errorf("jwtutil: %T: %w", key, jwt.ErrKeyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&block), nil
+}
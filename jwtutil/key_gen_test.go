@@ -0,0 +1,93 @@
+package jwtutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateKey(t *testing.T) {
+	testCases := []struct {
+		alg    string
+		kty    string
+		pemErr error
+	}{
+		{"HS256", "oct", jwt.ErrKeyType},
+		{"HS512", "oct", jwt.ErrKeyType},
+		{"RS256", "RSA", nil},
+		{"PS384", "RSA", nil},
+		{"ES256", "EC", nil},
+		{"ES384", "EC", nil},
+		{"ES512", "EC", nil},
+		{"EdDSA", "OKP", nil},
+		{"Ed25519", "OKP", nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alg, func(t *testing.T) {
+			key, err := jwtutil.GenerateKey(tc.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			alg, err := jwtutil.NewAlgorithm(tc.alg, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := jwt.Sign(jwt.Payload{Subject: tc.alg}, alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jwk, err := jwt.NewJWK(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.kty, jwk.KeyType; got != want {
+				t.Errorf("want %s, got %s", want, got)
+			}
+			if jwk.D == "" && jwk.K == "" {
+				t.Errorf("JWK is missing the private key")
+			}
+			if _, err = json.Marshal(jwk); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := jwtutil.MarshalPEM(key)
+			if want, got := tc.pemErr, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwtutil.MarshalPEM err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			parsed, err := jwtutil.ParseAlgorithm(tc.alg, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			if _, err = jwt.Verify(token, parsed, &pl); err != nil {
+				t.Fatal(err)
+			}
+
+			// Public keys are exported too.
+			pub := alg.(jwt.PublicKeyAlgorithm).Public()
+			if b, err = jwtutil.MarshalPEM(pub); err != nil {
+				t.Fatal(err)
+			}
+			if parsed, err = jwtutil.ParseAlgorithm(tc.alg, b); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = jwt.Verify(token, parsed, &pl); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	if _, err := jwtutil.GenerateKey("none"); !internal.ErrorIs(err, jwt.ErrAlgUnsupported) {
+		t.Errorf("want %v, got %v", jwt.ErrAlgUnsupported, err)
+	}
+	if _, err := jwtutil.GenerateKey("ES256K"); !internal.ErrorIs(err, jwt.ErrES256KPrivateKey) {
+		t.Errorf("want %v, got %v", jwt.ErrES256KPrivateKey, err)
+	}
+}
@@ -0,0 +1,219 @@
+package jwtutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrKeyNotFound is the error for when a key ring has no key with a given ID.
+	ErrKeyNotFound = internal.NewError("jwtutil: key not found")
+	// ErrKeyRetired is the error for verifying a token signed with a retired key.
+	ErrKeyRetired = internal.NewError("jwtutil: key is retired")
+	// ErrNoActiveKey is the error for signing when a key ring has no active key currently valid.
+	ErrNoActiveKey = internal.NewError("jwtutil: no active key")
+	// ErrDuplicateKey is the error for adding a key whose ID is already in use.
+	ErrDuplicateKey = internal.NewError("jwtutil: duplicate key ID")
+	// ErrInvalidKey is the error for adding a key without an ID or an algorithm.
+	ErrInvalidKey = internal.NewError("jwtutil: key must have an ID and an algorithm")
+)
+
+// KeyStatus is the status of a key in a KeyRing.
+type KeyStatus uint8
+
+const (
+	// KeyActive keys may be used for both signing and verifying.
+	KeyActive KeyStatus = iota
+	// KeyVerifyOnly keys may only be used for verifying, such as keys being phased in or out.
+	KeyVerifyOnly
+	// KeyRetired keys may not be used at all.
+	KeyRetired
+)
+
+// String returns the status' name.
+func (st KeyStatus) String() string {
+	switch st {
+	case KeyActive:
+		return "active"
+	case KeyVerifyOnly:
+		return "verify-only"
+	case KeyRetired:
+		return "retired"
+	}
+	return "unknown"
+}
+
+// Key is a key held by a KeyRing.
+type Key struct {
+	// ID is used as the "kid" header parameter.
+	ID string
+	// Algorithm signs and verifies tokens using the key.
+	Algorithm jwt.Algorithm
+	// NotBefore and NotAfter bound when an active key may sign tokens. Zero values are unbounded.
+	// They don't affect verification, so tokens signed near the end of a key's validity can still
+	// be verified until the key is retired.
+	NotBefore time.Time
+	NotAfter  time.Time
+	// Status defines whether the key may sign, verify or neither.
+	Status KeyStatus
+}
+
+// validAt reports whether k may sign at t.
+func (k Key) validAt(t time.Time) bool {
+	return k.Status == KeyActive &&
+		(k.NotBefore.IsZero() || !t.Before(k.NotBefore)) &&
+		(k.NotAfter.IsZero() || t.Before(k.NotAfter))
+}
+
+// KeyRing holds several keys identified by ID, which allows rotating them without downtime:
+// a new key is added as verify-only, then made active, then the previous key is made verify-only
+// and, once tokens signed with it have expired, retired.
+//
+// A KeyRing is safe for concurrent use, so it can be updated while tokens are signed and verified.
+type KeyRing struct {
+	mu   sync.RWMutex
+	keys map[string]Key
+	now  func() time.Time
+}
+
+// NewKeyRing creates a KeyRing holding keys.
+func NewKeyRing(keys ...Key) (*KeyRing, error) {
+	kr := &KeyRing{
+		keys: make(map[string]Key, len(keys)),
+		now:  time.Now,
+	}
+	for _, k := range keys {
+		if err := kr.Add(k); err != nil {
+			return nil, err
+		}
+	}
+	return kr, nil
+}
+
+// Add adds k to the ring.
+func (kr *KeyRing) Add(k Key) error {
+	if k.ID == "" || k.Algorithm == nil {
+		return ErrInvalidKey
+	}
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if _, ok := kr.keys[k.ID]; ok {
+		return internal.Errorf("jwtutil: %q: %w", k.ID, ErrDuplicateKey)
+	}
+	kr.keys[k.ID] = k
+	return nil
+}
+
+// Remove removes the key whose ID is kid, if any.
+func (kr *KeyRing) Remove(kid string) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	delete(kr.keys, kid)
+}
+
+// SetStatus sets the status of the key whose ID is kid.
+func (kr *KeyRing) SetStatus(kid string, st KeyStatus) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	k, ok := kr.keys[kid]
+	if !ok {
+		return internal.Errorf("jwtutil: %q: %w", kid, ErrKeyNotFound)
+	}
+	k.Status = st
+	kr.keys[kid] = k
+	return nil
+}
+
+// Key returns the key whose ID is kid.
+func (kr *KeyRing) Key(kid string) (Key, error) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	k, ok := kr.keys[kid]
+	if !ok {
+		return Key{}, internal.Errorf("jwtutil: %q: %w", kid, ErrKeyNotFound)
+	}
+	return k, nil
+}
+
+// Keys returns all keys in the ring, sorted by ID.
+func (kr *KeyRing) Keys() []Key {
+	kr.mu.RLock()
+	keys := make([]Key, 0, len(kr.keys))
+	for _, k := range kr.keys {
+		keys = append(keys, k)
+	}
+	kr.mu.RUnlock()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+// Active returns the key currently used for signing, which is the active key within its validity
+// that became valid most recently. Ties are broken by the greatest ID.
+func (kr *KeyRing) Active() (Key, error) {
+	now := kr.now()
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	var (
+		active Key
+		found  bool
+	)
+	for _, k := range kr.keys {
+		if !k.validAt(now) {
+			continue
+		}
+		if !found || k.NotBefore.After(active.NotBefore) ||
+			(k.NotBefore.Equal(active.NotBefore) && k.ID > active.ID) {
+			active, found = k, true
+		}
+	}
+	if !found {
+		return Key{}, ErrNoActiveKey
+	}
+	return active, nil
+}
+
+// Sign signs payload with the active key, setting the "kid" header parameter to its ID.
+func (kr *KeyRing) Sign(payload interface{}, opts ...jwt.SignOption) ([]byte, error) {
+	return kr.SignContext(context.Background(), payload, opts...)
+}
+
+// SignContext signs payload the same way Sign does, but passing ctx to the key's algorithm.
+func (kr *KeyRing) SignContext(ctx context.Context, payload interface{}, opts ...jwt.SignOption) ([]byte, error) {
+	k, err := kr.Active()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], jwt.KeyID(k.ID)) // don't write to the caller's array
+	return jwt.SignContext(ctx, payload, k.Algorithm, opts...)
+}
+
+// Algorithm returns the algorithm for verifying a token with hd, which is the algorithm of
+// the key whose ID is the "kid" header parameter, as long as the key is not retired.
+// It can be used as a Resolver's New function.
+func (kr *KeyRing) Algorithm(hd jwt.Header) (jwt.Algorithm, error) {
+	k, err := kr.Key(hd.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if k.Status == KeyRetired {
+		return nil, internal.Errorf("jwtutil: %q: %w", k.ID, ErrKeyRetired)
+	}
+	return k.Algorithm, nil
+}
+
+// Verify verifies token with the key whose ID is the token's "kid" header parameter.
+// The header's "alg" must match the key's algorithm.
+func (kr *KeyRing) Verify(token []byte, payload interface{}, opts ...jwt.VerifyOption) (jwt.Header, error) {
+	return kr.VerifyContext(context.Background(), token, payload, opts...)
+}
+
+// VerifyContext verifies token the same way Verify does, but passing ctx to the key's algorithm.
+func (kr *KeyRing) VerifyContext(ctx context.Context, token []byte, payload interface{}, opts ...jwt.VerifyOption) (jwt.Header, error) {
+	rv := &Resolver{New: kr.Algorithm}
+	return jwt.VerifyContext(ctx, token, rv, payload, append([]jwt.VerifyOption{jwt.ValidateHeader}, opts...)...)
+}
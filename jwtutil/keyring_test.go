@@ -0,0 +1,222 @@
+package jwtutil_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestKeyRingActive(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name string
+		keys []jwtutil.Key
+		want string
+		err  error
+	}{
+		{"empty", nil, "", jwtutil.ErrNoActiveKey},
+		{"unbounded", []jwtutil.Key{{ID: "a", Algorithm: hs256}}, "a", nil},
+		{"verify-only", []jwtutil.Key{{ID: "a", Algorithm: hs256, Status: jwtutil.KeyVerifyOnly}}, "", jwtutil.ErrNoActiveKey},
+		{"newest", []jwtutil.Key{
+			{ID: "a", Algorithm: hs256, NotBefore: now.Add(-2 * time.Hour)},
+			{ID: "b", Algorithm: hs256, NotBefore: now.Add(-time.Hour)},
+		}, "b", nil},
+		{"not yet valid", []jwtutil.Key{
+			{ID: "a", Algorithm: hs256, NotBefore: now.Add(-time.Hour)},
+			{ID: "b", Algorithm: hs256, NotBefore: now.Add(time.Hour)},
+		}, "a", nil},
+		{"expired", []jwtutil.Key{
+			{ID: "a", Algorithm: hs256, NotAfter: now.Add(-time.Hour)},
+			{ID: "b", Algorithm: hs256, NotBefore: now.Add(-2 * time.Hour), NotAfter: now.Add(time.Hour)},
+		}, "b", nil},
+		{"retired", []jwtutil.Key{
+			{ID: "a", Algorithm: hs256, NotBefore: now.Add(-2 * time.Hour)},
+			{ID: "b", Algorithm: hs256, NotBefore: now.Add(-time.Hour), Status: jwtutil.KeyRetired},
+		}, "a", nil},
+		{"tie", []jwtutil.Key{{ID: "a", Algorithm: hs256}, {ID: "b", Algorithm: hs256}}, "b", nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kr, err := jwtutil.NewKeyRing(tc.keys...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			k, err := kr.Active()
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwtutil.KeyRing.Active err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.want, k.ID; got != want {
+				t.Errorf("want %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestKeyRingRotation(t *testing.T) {
+	var (
+		oldAlg = jwt.NewHS256([]byte("old"))
+		newAlg = jwt.NewHS384([]byte("new"))
+	)
+	kr, err := jwtutil.NewKeyRing(jwtutil.Key{ID: "old", Algorithm: oldAlg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, err := kr.Sign(jwt.Payload{Subject: "old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Phase the new key in, then make it the active one.
+	if err = kr.Add(jwtutil.Key{ID: "new", Algorithm: newAlg, Status: jwtutil.KeyVerifyOnly}); err != nil {
+		t.Fatal(err)
+	}
+	if err = kr.SetStatus("new", jwtutil.KeyActive); err != nil {
+		t.Fatal(err)
+	}
+	if err = kr.SetStatus("old", jwtutil.KeyVerifyOnly); err != nil {
+		t.Fatal(err)
+	}
+	newToken, err := kr.Sign(jwt.Payload{Subject: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		token []byte
+		kid   string
+		err   error
+	}{
+		{oldToken, "old", nil},
+		{newToken, "new", nil},
+	}
+	for _, tc := range testCases {
+		var pl jwt.Payload
+		hd, err := kr.Verify(tc.token, &pl)
+		if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+			t.Fatalf("jwtutil.KeyRing.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		if want, got := tc.kid, hd.KeyID; got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+		if want, got := tc.kid, pl.Subject; got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	}
+
+	// Once retired, the old key can't verify anymore.
+	if err = kr.SetStatus("old", jwtutil.KeyRetired); err != nil {
+		t.Fatal(err)
+	}
+	var pl jwt.Payload
+	if _, err = kr.Verify(oldToken, &pl); !internal.ErrorIs(err, jwtutil.ErrKeyRetired) {
+		t.Errorf("want %v, got %v", jwtutil.ErrKeyRetired, err)
+	}
+	kr.Remove("old")
+	if _, err = kr.Verify(oldToken, &pl); !internal.ErrorIs(err, jwtutil.ErrKeyNotFound) {
+		t.Errorf("want %v, got %v", jwtutil.ErrKeyNotFound, err)
+	}
+	if err = kr.SetStatus("old", jwtutil.KeyActive); !internal.ErrorIs(err, jwtutil.ErrKeyNotFound) {
+		t.Errorf("want %v, got %v", jwtutil.ErrKeyNotFound, err)
+	}
+}
+
+func TestKeyRingErrors(t *testing.T) {
+	kr, err := jwtutil.NewKeyRing(jwtutil.Key{ID: "hs256", Algorithm: hs256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = kr.Add(jwtutil.Key{ID: "hs256", Algorithm: hs256}); !internal.ErrorIs(err, jwtutil.ErrDuplicateKey) {
+		t.Errorf("want %v, got %v", jwtutil.ErrDuplicateKey, err)
+	}
+	if err = kr.Add(jwtutil.Key{ID: "nil"}); !internal.ErrorIs(err, jwtutil.ErrInvalidKey) {
+		t.Errorf("want %v, got %v", jwtutil.ErrInvalidKey, err)
+	}
+
+	// A token claiming another algorithm for the same key ID is rejected.
+	token, err := jwt.Sign(jwt.Payload{}, jwt.NewHS512([]byte("resolver")), jwt.KeyID("hs256"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pl jwt.Payload
+	if _, err = kr.Verify(token, &pl); !internal.ErrorIs(err, jwt.ErrAlgValidation) {
+		t.Errorf("want %v, got %v", jwt.ErrAlgValidation, err)
+	}
+}
+
+func TestKeyRingAliases(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name      string
+		signAlg   jwt.Algorithm
+		verifyAlg jwt.Algorithm
+	}{
+		{"Ed25519 key, EdDSA token", jwt.NewEdDSA(jwt.EdDSAPrivateKey(priv)), jwt.NewEd25519(jwt.Ed25519PublicKey(pub))},
+		{"EdDSA key, Ed25519 token", jwt.NewEd25519(jwt.Ed25519PrivateKey(priv)), jwt.NewEdDSA(jwt.EdDSAPublicKey(pub))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kr, err := jwtutil.NewKeyRing(jwtutil.Key{ID: "ed", Algorithm: tc.verifyAlg})
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := jwt.Sign(jwt.Payload{Subject: "ed"}, tc.signAlg, jwt.KeyID("ed"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			if _, err = kr.Verify(token, &pl); err != nil {
+				t.Fatal(err)
+			}
+			if want, got := "ed", pl.Subject; got != want {
+				t.Errorf("want %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestKeyRingConcurrency(t *testing.T) {
+	kr, err := jwtutil.NewKeyRing(jwtutil.Key{ID: "0", Algorithm: hs256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			kid := fmt.Sprint(i)
+			if err := kr.Add(jwtutil.Key{ID: kid, Algorithm: hs256, Status: jwtutil.KeyVerifyOnly}); err != nil {
+				t.Error(err)
+			}
+			if err := kr.SetStatus(kid, jwtutil.KeyActive); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			token, err := kr.Sign(jwt.Payload{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			var pl jwt.Payload
+			if _, err = kr.Verify(token, &pl); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if want, got := 9, len(kr.Keys()); got != want {
+		t.Errorf("want %d, got %d", want, got)
+	}
+}
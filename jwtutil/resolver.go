@@ -1,6 +1,8 @@
 package jwtutil
 
 import (
+	"context"
+
 	"github.com/gbrlsnchs/jwt/v3"
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -8,9 +10,17 @@ import (
 // Resolver is an Algorithm resolver.
 type Resolver struct {
 	New func(jwt.Header) (jwt.Algorithm, error)
-	alg jwt.Algorithm
+	// NewContext is used instead of New when set, and receives
+	// the context passed to jwt.SignContext or jwt.VerifyContext.
+	NewContext func(context.Context, jwt.Header) (jwt.Algorithm, error)
+	alg        jwt.Algorithm
 }
 
+var (
+	_ jwt.ContextResolver  = new(Resolver)
+	_ jwt.ContextAlgorithm = new(Resolver)
+)
+
 // ErrNilAlg is the error for when an algorithm can't be resolved.
 var ErrNilAlg = internal.NewError("algorithm is nil")
 
@@ -22,15 +32,37 @@ func (rv *Resolver) Name() string {
 	return rv.alg.Name()
 }
 
+// Aliases returns the aliases of the resolved Algorithm, if it has any, so jwt.ValidateHeader
+// accepts them as it would with the Algorithm itself, such as "EdDSA" for Ed25519 keys.
+func (rv *Resolver) Aliases() []string {
+	if a, ok := rv.alg.(interface{ Aliases() []string }); ok {
+		return a.Aliases()
+	}
+	return nil
+}
+
 // Resolve sets an Algorithm based on a JOSE Header.
 func (rv *Resolver) Resolve(hd jwt.Header) error {
+	return rv.ResolveContext(context.Background(), hd)
+}
+
+// ResolveContext sets an Algorithm based on a JOSE Header, passing ctx to NewContext.
+func (rv *Resolver) ResolveContext(ctx context.Context, hd jwt.Header) error {
 	if rv.alg != nil {
 		return nil
 	}
-	if rv.New == nil {
+	var (
+		alg jwt.Algorithm
+		err error
+	)
+	switch {
+	case rv.NewContext != nil:
+		alg, err = rv.NewContext(ctx, hd)
+	case rv.New != nil:
+		alg, err = rv.New(hd)
+	default:
 		return ErrNilAlg
 	}
-	alg, err := rv.New(hd)
 	if err != nil {
 		return err
 	}
@@ -46,6 +78,11 @@ func (rv *Resolver) Sign(headerPayload []byte) ([]byte, error) {
 	return rv.alg.Sign(headerPayload)
 }
 
+// SignContext signs using the resolved Algorithm, passing ctx to it.
+func (rv *Resolver) SignContext(ctx context.Context, headerPayload []byte) ([]byte, error) {
+	return jwt.WithContext(rv.alg).SignContext(ctx, headerPayload)
+}
+
 // Size returns an Algorithm's size.
 func (rv *Resolver) Size() int {
 	return rv.alg.Size()
@@ -55,3 +92,8 @@ func (rv *Resolver) Size() int {
 func (rv *Resolver) Verify(headerPayload, sig []byte) error {
 	return rv.alg.Verify(headerPayload, sig)
 }
+
+// VerifyContext verifies using the resolved Algorithm, passing ctx to it.
+func (rv *Resolver) VerifyContext(ctx context.Context, headerPayload, sig []byte) error {
+	return jwt.WithContext(rv.alg).VerifyContext(ctx, headerPayload, sig)
+}
@@ -1,6 +1,7 @@
 package jwtutil_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -50,3 +51,35 @@ func TestResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestResolverContext(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	token, err := jwt.Sign(jwt.Payload{}, hs256, jwt.KeyID("kid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv := &jwtutil.Resolver{
+		NewContext: func(ctx context.Context, hd jwt.Header) (jwt.Algorithm, error) {
+			if ctx.Value(ctxKey{}) != "value" {
+				return nil, errors.New("context wasn't passed")
+			}
+			return hs256, nil
+		},
+	}
+	var pl jwt.Payload
+	if _, err = jwt.VerifyContext(ctx, token, rv, &pl); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	rv = &jwtutil.Resolver{
+		NewContext: func(ctx context.Context, hd jwt.Header) (jwt.Algorithm, error) {
+			return nil, ctx.Err()
+		},
+	}
+	if _, err = jwt.VerifyContext(ctx, token, rv, &pl); err != context.Canceled {
+		t.Errorf("want %v, got %v", context.Canceled, err)
+	}
+}
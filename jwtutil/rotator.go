@@ -0,0 +1,463 @@
+package jwtutil
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrRotatorPassphrase is the error for a persisted key that can't be decrypted with the passphrase.
+	ErrRotatorPassphrase = internal.NewError("jwtutil: wrong passphrase or corrupted key file")
+	// ErrRotatorKeyFile is the error for a malformed persisted key.
+	ErrRotatorKeyFile = internal.NewError("jwtutil: invalid key file")
+	// ErrRotatorUnencrypted is the error for a persisted key that is not encrypted although a passphrase is set.
+	ErrRotatorUnencrypted = internal.NewError("jwtutil: key file is not encrypted")
+	// ErrRotatorSchedule is the error for rotation durations that are not positive.
+	ErrRotatorSchedule = internal.NewError("jwtutil: rotation durations must be positive")
+)
+
+const (
+	rotatorFileExt = ".json"
+	rotatorKeySize = 32 // AES-256
+	rotatorIDSize  = 12
+)
+
+// rotatorAlgs are the algorithms whose keys can be persisted as PKCS #8.
+var rotatorAlgs = map[string]bool{
+	"RS256":   true,
+	"RS384":   true,
+	"RS512":   true,
+	"PS256":   true,
+	"PS384":   true,
+	"PS512":   true,
+	"ES256":   true,
+	"ES384":   true,
+	"ES512":   true,
+	"EdDSA":   true,
+	"Ed25519": true,
+}
+
+// Rotator generates keys on a schedule and keeps a KeyRing up to date with them.
+//
+// Each key is published a while before its validity starts, so relying parties may fetch it before
+// it signs anything, and the KeyRing starts signing with it once its NotBefore passes, without
+// waiting for Rotate. It signs for a rotation interval, after which it stays verify-only for the
+// max token lifetime, so tokens it signed can still be verified, and is finally retired, which
+// removes it from both the ring and the directory.
+//
+// Keys are persisted to a directory, so restarts keep the same keys. If a passphrase is set,
+// private keys are encrypted with AES-256-GCM using a key derived from it with scrypt, and
+// unencrypted keys found in the directory are rejected.
+type Rotator struct {
+	mu           sync.Mutex
+	ring         *KeyRing
+	alg          string
+	dir          string
+	passphrase   []byte
+	interval     time.Duration
+	publishDelay time.Duration
+	maxLifetime  time.Duration
+	keys         map[string]rotatorKey
+	now          func() time.Time
+}
+
+type rotatorKey struct {
+	id        string
+	alg       jwt.Algorithm
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// status returns the key's status at t and whether it must be retired. Keys whose validity hasn't
+// started are already active, since the KeyRing doesn't sign with them before their NotBefore, so
+// signing switches to them on time even if Rotate runs late.
+func (k rotatorKey) status(t time.Time, maxLifetime time.Duration) (KeyStatus, bool) {
+	switch {
+	case t.Before(k.notAfter):
+		return KeyActive, false
+	case t.Before(k.notAfter.Add(maxLifetime)):
+		return KeyVerifyOnly, false
+	}
+	return KeyRetired, true
+}
+
+// rotatorFile is how a key is persisted.
+type rotatorFile struct {
+	ID        string    `json:"kid"`
+	Algorithm string    `json:"alg"`
+	NotBefore time.Time `json:"nbf"`
+	NotAfter  time.Time `json:"exp"`
+	// Key is a PKCS #8 private key, encrypted if Salt and Nonce are set.
+	Key   []byte `json:"key"`
+	Salt  []byte `json:"salt,omitempty"`
+	Nonce []byte `json:"nonce,omitempty"`
+}
+
+// RotationInterval sets for how long each key is active. The default is 30 days.
+func RotationInterval(d time.Duration) func(*Rotator) {
+	return func(r *Rotator) {
+		r.interval = d
+	}
+}
+
+// RotationPublishDelay sets for how long each new key is published before it starts signing.
+// The default is 24 hours.
+func RotationPublishDelay(d time.Duration) func(*Rotator) {
+	return func(r *Rotator) {
+		r.publishDelay = d
+	}
+}
+
+// RotationMaxTokenLifetime sets the lifetime of the longest lived token signed by a key,
+// for which the key is kept as verify-only after its rotation. The default is 24 hours.
+func RotationMaxTokenLifetime(d time.Duration) func(*Rotator) {
+	return func(r *Rotator) {
+		r.maxLifetime = d
+	}
+}
+
+// RotationPassphrase sets a passphrase for encrypting persisted keys.
+func RotationPassphrase(passphrase []byte) func(*Rotator) {
+	return func(r *Rotator) {
+		r.passphrase = passphrase
+	}
+}
+
+// RotationClock sets the function returning the current time, which is time.Now by default.
+// It is also used by the Rotator's KeyRing. Since Run waits for durations computed from it,
+// a clock that doesn't follow real time, such as in tests, should be used with Rotate instead.
+func RotationClock(now func() time.Time) func(*Rotator) {
+	return func(r *Rotator) {
+		r.now = now
+	}
+}
+
+// NewRotator creates a Rotator for the algorithm named alg, which must be one of "RS256", "RS384",
+// "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA" or "Ed25519", persisting
+// keys to dir. Keys already in dir are loaded and, if none is current, a new key is generated, so
+// the returned Rotator is always ready for signing.
+func NewRotator(alg, dir string, opts ...func(*Rotator)) (*Rotator, error) {
+	if !rotatorAlgs[alg] {
+		return nil, internal.Errorf("jwtutil: %q: %w", alg, jwt.ErrAlgUnsupported)
+	}
+	ring, err := NewKeyRing()
+	if err != nil {
+		return nil, err
+	}
+	r := &Rotator{
+		ring:         ring,
+		alg:          alg,
+		dir:          dir,
+		interval:     30 * 24 * time.Hour,
+		publishDelay: 24 * time.Hour,
+		maxLifetime:  24 * time.Hour,
+		keys:         make(map[string]rotatorKey),
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.interval <= 0 || r.publishDelay < 0 || r.maxLifetime < 0 {
+		return nil, ErrRotatorSchedule
+	}
+	ring.now = r.now
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	if err = r.load(); err != nil {
+		return nil, err
+	}
+	if err = r.Rotate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Ring returns the key ring kept up to date, which signs with the active key and
+// verifies with any published key.
+func (r *Rotator) Ring() *KeyRing {
+	return r.ring
+}
+
+// Rotate updates the keys' statuses for the current time, retiring expired keys and generating
+// new keys so that the key succeeding the active one is always published a publish delay ahead.
+func (r *Rotator) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	var last time.Time // when the last key stops being active
+	for id, k := range r.keys {
+		st, retire := k.status(now, r.maxLifetime)
+		if retire {
+			r.ring.Remove(id)
+			delete(r.keys, id)
+			if err := os.Remove(r.path(id)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := r.ring.SetStatus(id, st); err != nil {
+			return err
+		}
+		if k.notAfter.After(last) {
+			last = k.notAfter
+		}
+	}
+	for !now.Before(last.Add(-r.publishDelay)) {
+		nbf := last
+		if nbf.Before(now) {
+			// There's no current key, such as on the first run or after a long
+			// downtime, so the new key is active right away.
+			nbf = now
+		}
+		last = nbf.Add(r.interval)
+		if err := r.generate(nbf, last, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run rotates keys whenever their schedule requires it, until ctx is done or rotation fails.
+func (r *Rotator) Run(ctx context.Context) error {
+	for {
+		if err := r.Rotate(); err != nil {
+			return err
+		}
+		t := time.NewTimer(r.next().Sub(r.now()))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// next returns when keys' statuses change next.
+func (r *Rotator) next() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var (
+		now  = r.now()
+		next time.Time
+		last time.Time
+	)
+	consider := func(t time.Time) {
+		if t.After(now) && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	for _, k := range r.keys {
+		consider(k.notBefore)
+		consider(k.notAfter)
+		consider(k.notAfter.Add(r.maxLifetime))
+		if k.notAfter.After(last) {
+			last = k.notAfter
+		}
+	}
+	consider(last.Add(-r.publishDelay))
+	if next.IsZero() {
+		next = now
+	}
+	return next
+}
+
+func (r *Rotator) path(kid string) string {
+	return filepath.Join(r.dir, kid+rotatorFileExt)
+}
+
+// generate generates and persists a new key valid from nbf to exp, publishing it at now.
+func (r *Rotator) generate(nbf, exp, now time.Time) error {
+	id := make([]byte, rotatorIDSize)
+	if _, err := io.ReadFull(rand.Reader, id); err != nil {
+		return err
+	}
+	priv, err := GenerateKey(r.alg)
+	if err != nil {
+		return err
+	}
+	k := rotatorKey{
+		id:        base64.RawURLEncoding.EncodeToString(id),
+		notBefore: nbf.Round(0),
+		notAfter:  exp.Round(0),
+	}
+	if k.alg, err = NewAlgorithm(r.alg, priv); err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	f := rotatorFile{
+		ID:        k.id,
+		Algorithm: r.alg,
+		NotBefore: k.notBefore,
+		NotAfter:  k.notAfter,
+		Key:       der,
+	}
+	if len(r.passphrase) > 0 {
+		if err = f.encrypt(r.passphrase); err != nil {
+			return err
+		}
+	}
+	if err = r.save(f); err != nil {
+		return err
+	}
+	return r.add(k, now)
+}
+
+func (r *Rotator) add(k rotatorKey, now time.Time) error {
+	st, _ := k.status(now, r.maxLifetime)
+	err := r.ring.Add(Key{
+		ID:        k.id,
+		Algorithm: k.alg,
+		NotBefore: k.notBefore,
+		NotAfter:  k.notAfter,
+		Status:    st,
+	})
+	if err != nil {
+		return err
+	}
+	r.keys[k.id] = k
+	return nil
+}
+
+// save writes f to the directory atomically, so a crash never leaves a partial key behind.
+func (r *Rotator) save(f rotatorFile) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(r.dir, "."+f.ID+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path(f.ID))
+}
+
+// load loads the keys persisted in the directory. Keys for other algorithms are ignored,
+// but unencrypted keys are rejected if a passphrase is set, since they may have been planted.
+func (r *Rotator) load() error {
+	paths, err := filepath.Glob(filepath.Join(r.dir, "*"+rotatorFileExt))
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	for _, p := range paths {
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		var f rotatorFile
+		if err = json.Unmarshal(b, &f); err != nil {
+			return internal.Errorf("jwtutil: %s: %v: %w", p, err, ErrRotatorKeyFile)
+		}
+		if f.ID+rotatorFileExt != filepath.Base(p) {
+			return internal.Errorf("jwtutil: %s: key ID mismatch: %w", p, ErrRotatorKeyFile)
+		}
+		if f.Algorithm != r.alg {
+			continue
+		}
+		switch {
+		case f.Salt != nil || f.Nonce != nil:
+			if err = f.decrypt(r.passphrase); err != nil {
+				return internal.Errorf("jwtutil: %s: %w", p, err)
+			}
+		case len(r.passphrase) > 0:
+			return internal.Errorf("jwtutil: %s: %w", p, ErrRotatorUnencrypted)
+		}
+		priv, err := x509.ParsePKCS8PrivateKey(f.Key)
+		if err != nil {
+			return internal.Errorf("jwtutil: %s: %v: %w", p, err, ErrRotatorKeyFile)
+		}
+		k := rotatorKey{
+			id:        f.ID,
+			notBefore: f.NotBefore,
+			notAfter:  f.NotAfter,
+		}
+		if k.alg, err = NewAlgorithm(r.alg, priv); err != nil {
+			return internal.Errorf("jwtutil: %s: %w", p, err)
+		}
+		if err = r.add(k, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rotatorAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, rotatorKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt encrypts f's key with passphrase, authenticating its metadata so it can't be tampered with.
+func (f *rotatorFile) encrypt(passphrase []byte) error {
+	f.Salt = make([]byte, rotatorKeySize)
+	if _, err := io.ReadFull(rand.Reader, f.Salt); err != nil {
+		return err
+	}
+	aead, err := rotatorAEAD(passphrase, f.Salt)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, f.Nonce); err != nil {
+		return err
+	}
+	f.Key = aead.Seal(nil, f.Nonce, f.Key, f.metadata())
+	return nil
+}
+
+func (f *rotatorFile) decrypt(passphrase []byte) error {
+	aead, err := rotatorAEAD(passphrase, f.Salt)
+	if err != nil {
+		return err
+	}
+	if len(f.Nonce) != aead.NonceSize() {
+		return ErrRotatorKeyFile
+	}
+	if f.Key, err = aead.Open(nil, f.Nonce, f.Key, f.metadata()); err != nil {
+		return ErrRotatorPassphrase
+	}
+	return nil
+}
+
+func (f *rotatorFile) metadata() []byte {
+	return []byte(f.ID + "." + f.Algorithm + "." +
+		f.NotBefore.UTC().Format(time.RFC3339Nano) + "." + f.NotAfter.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,281 @@
+package jwtutil_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "jwtutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func keyStatuses(kr *jwtutil.KeyRing) map[string]jwtutil.KeyStatus {
+	m := make(map[string]jwtutil.KeyStatus)
+	for _, k := range kr.Keys() {
+		m[k.ID] = k.Status
+	}
+	return m
+}
+
+func TestRotator(t *testing.T) {
+	testCases := []struct {
+		alg  string
+		opts []func(*jwtutil.Rotator)
+	}{
+		{"RS256", nil},
+		{"PS384", nil},
+		{"ES256", nil},
+		{"ES512", nil},
+		{"EdDSA", nil},
+		{"Ed25519", []func(*jwtutil.Rotator){jwtutil.RotationPassphrase([]byte("passphrase"))}},
+		{"ES384", []func(*jwtutil.Rotator){jwtutil.RotationPassphrase([]byte("passphrase"))}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alg, func(t *testing.T) {
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			opts := append([]func(*jwtutil.Rotator){
+				jwtutil.RotationInterval(time.Hour),
+				jwtutil.RotationPublishDelay(90 * time.Minute),
+			}, tc.opts...)
+			r, err := jwtutil.NewRotator(tc.alg, dir, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The first key is active right away, and the publish delay
+			// spanning more than an interval requires a second key.
+			keys := r.Ring().Keys()
+			if want, got := 2, len(keys); got != want {
+				t.Fatalf("want %d keys, got %d", want, got)
+			}
+			active, err := r.Ring().Active()
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := r.Ring().Sign(jwt.Payload{Subject: tc.alg})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Restarting keeps the same keys.
+			r, err = jwtutil.NewRotator(tc.alg, dir, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := 2, len(r.Ring().Keys()); got != want {
+				t.Fatalf("want %d keys, got %d", want, got)
+			}
+			var pl jwt.Payload
+			hd, err := r.Ring().Verify(token, &pl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := active.ID, hd.KeyID; got != want {
+				t.Errorf("want %q, got %q", want, got)
+			}
+			// The next key is already active, but it doesn't sign before its validity starts.
+			for _, k := range keys {
+				if want, got := jwtutil.KeyActive, keyStatuses(r.Ring())[k.ID]; got != want {
+					t.Errorf("%s: want %v, got %v", k.ID, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRotatorRetirement(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	now := time.Now()
+	r, err := jwtutil.NewRotator("ES256", dir,
+		jwtutil.RotationInterval(time.Hour),
+		jwtutil.RotationPublishDelay(0),
+		jwtutil.RotationMaxTokenLifetime(0),
+		jwtutil.RotationClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := r.Ring().Active()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Hour)
+	if err = r.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Ring().Key(first.ID); !internal.ErrorIs(err, jwtutil.ErrKeyNotFound) {
+		t.Errorf("want %v, got %v", jwtutil.ErrKeyNotFound, err)
+	}
+	active, err := r.Ring().Active()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 1, len(files); got != want {
+		t.Fatalf("want %d files, got %d", want, got)
+	}
+	if want, got := active.ID+".json", filepath.Base(files[0]); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestRotatorBoundary(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	now := time.Now()
+	r, err := jwtutil.NewRotator("ES256", dir,
+		jwtutil.RotationInterval(time.Hour),
+		jwtutil.RotationPublishDelay(10*time.Minute),
+		jwtutil.RotationClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := r.Ring().Active()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Once the publish delay is reached, the next key is published.
+	now = now.Add(50 * time.Minute)
+	if err = r.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	// Crossing the boundary without calling Rotate switches to the next key.
+	now = first.NotAfter.Add(time.Millisecond)
+	next, err := r.Ring().Active()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.ID == first.ID {
+		t.Fatalf("key %q is still active", first.ID)
+	}
+	token, err := r.Ring().Sign(jwt.Payload{Subject: "boundary"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd, err := r.Ring().Verify(token, new(jwt.Payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := next.ID, hd.KeyID; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestRotatorRun(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	r, err := jwtutil.NewRotator("EdDSA", dir,
+		jwtutil.RotationInterval(5*time.Millisecond),
+		jwtutil.RotationPublishDelay(0),
+		jwtutil.RotationMaxTokenLifetime(0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := r.Ring().Active()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if want, got := context.DeadlineExceeded, r.Run(ctx); got != want {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	last, err := r.Ring().Active()
+	if err != nil {
+		// The active key may have just expired.
+		if err = r.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		last, err = r.Ring().Active()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if last.ID == first.ID {
+		t.Errorf("key %q was not rotated", first.ID)
+	}
+}
+
+func TestRotatorErrors(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	if _, err := jwtutil.NewRotator("RS256", dir, jwtutil.RotationPassphrase([]byte("right"))); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name string
+		alg  string
+		opts []func(*jwtutil.Rotator)
+		err  error
+	}{
+		{"unsupported", "HS256", nil, jwt.ErrAlgUnsupported},
+		{"schedule", "RS256", []func(*jwtutil.Rotator){jwtutil.RotationInterval(0)}, jwtutil.ErrRotatorSchedule},
+		{"no passphrase", "RS256", nil, jwtutil.ErrRotatorPassphrase},
+		{"wrong passphrase", "RS256", []func(*jwtutil.Rotator){jwtutil.RotationPassphrase([]byte("wrong"))}, jwtutil.ErrRotatorPassphrase},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := jwtutil.NewRotator(tc.alg, dir, tc.opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwtutil.NewRotator err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	// Tampering with a key's metadata is detected.
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f map[string]interface{}
+	if err = json.Unmarshal(b, &f); err != nil {
+		t.Fatal(err)
+	}
+	f["exp"] = time.Now().Add(24 * 365 * time.Hour)
+	if b, err = json.Marshal(f); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(files[0], b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = jwtutil.NewRotator("RS256", dir, jwtutil.RotationPassphrase([]byte("right")))
+	if want, got := jwtutil.ErrRotatorPassphrase, err; !internal.ErrorIs(got, want) {
+		t.Errorf("jwtutil.NewRotator err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestRotatorUnencrypted(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	if _, err := jwtutil.NewRotator("ES256", dir); err != nil {
+		t.Fatal(err)
+	}
+	_, err := jwtutil.NewRotator("ES256", dir, jwtutil.RotationPassphrase([]byte("passphrase")))
+	if want, got := jwtutil.ErrRotatorUnencrypted, err; !internal.ErrorIs(got, want) {
+		t.Errorf("jwtutil.NewRotator err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
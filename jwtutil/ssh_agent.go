@@ -0,0 +1,169 @@
+package jwtutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"math/big"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+var (
+	// ErrSSHAgentKeyNotFound is the error for when an agent has no key with a given fingerprint.
+	ErrSSHAgentKeyNotFound = internal.NewError("jwtutil: SSH agent key not found")
+	// ErrSSHAgentUnsupported is the error for when an agent can't sign using an algorithm.
+	ErrSSHAgentUnsupported = internal.NewError("jwtutil: SSH agent doesn't support algorithm")
+	// ErrSSHAgentSignature is the error for a signature in an unexpected format returned by an agent.
+	ErrSSHAgentSignature = internal.NewError("jwtutil: SSH agent returned an invalid signature")
+
+	_ jwt.PublicKeyAlgorithm = new(SSHAgent)
+)
+
+// SSHAgent is an algorithm that signs using a key held by an SSH agent,
+// such as ssh-agent, and verifies using the key's public part.
+type SSHAgent struct {
+	name   string
+	agent  agent.Agent
+	key    ssh.PublicKey
+	flags  agent.SignatureFlags
+	format string
+	verify jwt.Algorithm
+}
+
+type sshAgentParams struct {
+	keyType string // SSH key type
+	format  string // SSH signature format
+	flags   agent.SignatureFlags
+}
+
+var sshAgentAlgs = map[string]sshAgentParams{
+	"RS256":   {ssh.KeyAlgoRSA, ssh.SigAlgoRSASHA2256, agent.SignatureFlagRsaSha256},
+	"RS512":   {ssh.KeyAlgoRSA, ssh.SigAlgoRSASHA2512, agent.SignatureFlagRsaSha512},
+	"ES256":   {ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA256, 0},
+	"ES384":   {ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA384, 0},
+	"ES512":   {ssh.KeyAlgoECDSA521, ssh.KeyAlgoECDSA521, 0},
+	"EdDSA":   {ssh.KeyAlgoED25519, ssh.KeyAlgoED25519, 0},
+	"Ed25519": {ssh.KeyAlgoED25519, ssh.KeyAlgoED25519, 0},
+}
+
+// NewSSHAgent creates a new algorithm named name that signs with the key from ag whose fingerprint
+// is fingerprint, in either the SHA-256 ("SHA256:...") or the legacy MD5 format. Supported names
+// are "RS256" and "RS512" for RSA keys, which require an agent.ExtendedAgent, "ES256", "ES384"
+// and "ES512" for ECDSA keys, and "EdDSA" and "Ed25519" for Ed25519 keys.
+func NewSSHAgent(name string, ag agent.Agent, fingerprint string) (*SSHAgent, error) {
+	params, ok := sshAgentAlgs[name]
+	if !ok {
+		return nil, internal.Errorf("jwtutil: %q: %w", name, jwt.ErrAlgUnsupported)
+	}
+	if _, ok := ag.(agent.ExtendedAgent); params.flags != 0 && !ok {
+		return nil, internal.Errorf("jwtutil: %s requires signature flags: %w", name, ErrSSHAgentUnsupported)
+	}
+	keys, err := ag.List()
+	if err != nil {
+		return nil, err
+	}
+	var key ssh.PublicKey
+	for _, k := range keys {
+		if ssh.FingerprintSHA256(k) == fingerprint || ssh.FingerprintLegacyMD5(k) == fingerprint {
+			// Keys listed by agents only hold their wire format, so parse them.
+			if key, err = ssh.ParsePublicKey(k.Marshal()); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if key == nil {
+		return nil, internal.Errorf("jwtutil: %q: %w", fingerprint, ErrSSHAgentKeyNotFound)
+	}
+	if key.Type() != params.keyType {
+		return nil, internal.Errorf("jwtutil: %s key for %s: %w", key.Type(), name, jwt.ErrKeyType)
+	}
+	cpk, ok := key.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, internal.Errorf("jwtutil: %T for %s: %w", key, name, jwt.ErrKeyType)
+	}
+	sa := SSHAgent{
+		name:   name,
+		agent:  ag,
+		key:    key,
+		flags:  params.flags,
+		format: params.format,
+	}
+	switch pub := cpk.CryptoPublicKey().(type) {
+	case *rsa.PublicKey:
+		sa.verify, err = jwt.NewRSASHA(name, jwt.RSAPublicKey(pub))
+	case *ecdsa.PublicKey:
+		sa.verify, err = jwt.NewECDSASHA(name, jwt.ECDSAPublicKey(pub))
+	case ed25519.PublicKey:
+		sa.verify, err = jwt.NewEdDSASHA(jwt.EdDSAPublicKey(pub))
+	default:
+		err = internal.Errorf("jwtutil: %T for %s: %w", pub, name, jwt.ErrKeyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+// Name returns the algorithm's name.
+func (sa *SSHAgent) Name() string {
+	return sa.name
+}
+
+// Sign signs headerPayload using the SSH agent.
+func (sa *SSHAgent) Sign(headerPayload []byte) ([]byte, error) {
+	var (
+		sig *ssh.Signature
+		err error
+	)
+	if sa.flags != 0 {
+		sig, err = sa.agent.(agent.ExtendedAgent).SignWithFlags(sa.key, headerPayload, sa.flags)
+	} else {
+		sig, err = sa.agent.Sign(sa.key, headerPayload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sig.Format != sa.format {
+		return nil, internal.Errorf("jwtutil: %q signature for %s: %w", sig.Format, sa.name, ErrSSHAgentSignature)
+	}
+	if sa.key.Type() == ssh.KeyAlgoRSA || sa.key.Type() == ssh.KeyAlgoED25519 {
+		return sig.Blob, nil
+	}
+	// ECDSA signatures are encoded as two SSH mpints, as per the RFC 5656.
+	var rs struct {
+		R, S *big.Int
+	}
+	if err = ssh.Unmarshal(sig.Blob, &rs); err != nil {
+		return nil, internal.Errorf("jwtutil: %v: %w", err, ErrSSHAgentSignature)
+	}
+	size := sa.Size() / 2
+	rb, sb := rs.R.Bytes(), rs.S.Bytes()
+	if rs.R.Sign() <= 0 || rs.S.Sign() <= 0 || len(rb) > size || len(sb) > size {
+		return nil, ErrSSHAgentSignature
+	}
+	b := make([]byte, 2*size)
+	copy(b[size-len(rb):], rb)
+	copy(b[2*size-len(sb):], sb)
+	return b, nil
+}
+
+// Public returns the key's public part.
+func (sa *SSHAgent) Public() crypto.PublicKey {
+	return sa.key.(ssh.CryptoPublicKey).CryptoPublicKey()
+}
+
+// Size returns the signature's byte size.
+func (sa *SSHAgent) Size() int {
+	return sa.verify.Size()
+}
+
+// Verify verifies a signature based on headerPayload using the key's public part.
+func (sa *SSHAgent) Verify(headerPayload, sig []byte) error {
+	return sa.verify.Verify(headerPayload, sig)
+}
@@ -0,0 +1,106 @@
+package jwtutil_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestSSHAgent(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ag := agent.NewKeyring()
+	for _, k := range []interface{}{rsaKey, p256Key, p521Key, &edKey} {
+		if err = ag.Add(agent.AddedKey{PrivateKey: k}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fingerprint := func(k interface{}) string {
+		pub, err := ssh.NewPublicKey(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ssh.FingerprintSHA256(pub)
+	}
+	testCases := []struct {
+		name      string
+		pub       interface{}
+		verifyAlg jwt.Algorithm
+	}{
+		{"RS256", &rsaKey.PublicKey, jwt.NewRS256(jwt.RSAPublicKey(&rsaKey.PublicKey))},
+		{"RS512", &rsaKey.PublicKey, jwt.NewRS512(jwt.RSAPublicKey(&rsaKey.PublicKey))},
+		{"ES256", &p256Key.PublicKey, jwt.NewES256(jwt.ECDSAPublicKey(&p256Key.PublicKey))},
+		{"ES512", &p521Key.PublicKey, jwt.NewES512(jwt.ECDSAPublicKey(&p521Key.PublicKey))},
+		{"EdDSA", edPub, jwt.NewEdDSA(jwt.EdDSAPublicKey(edPub))},
+		{"Ed25519", edPub, jwt.NewEd25519(jwt.Ed25519PublicKey(edPub))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sa, err := jwtutil.NewSSHAgent(tc.name, ag, fingerprint(tc.pub))
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := jwt.Sign(jwt.Payload{Subject: "ssh-agent"}, sa)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, alg := range []jwt.Algorithm{sa, tc.verifyAlg} {
+				var pl jwt.Payload
+				if _, err = jwt.Verify(token, alg, &pl, jwt.ValidateHeader); err != nil {
+					t.Fatal(err)
+				}
+				if want, got := "ssh-agent", pl.Subject; got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		md5, err := ssh.NewPublicKey(&p256Key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testCases := []struct {
+			name        string
+			fingerprint string
+			err         error
+		}{
+			{"ES256", ssh.FingerprintLegacyMD5(md5), nil},
+			{"ES256", "SHA256:unknown", jwtutil.ErrSSHAgentKeyNotFound},
+			{"ES384", fingerprint(&p256Key.PublicKey), jwt.ErrKeyType},
+			{"RS256", fingerprint(edPub), jwt.ErrKeyType},
+			{"HS256", fingerprint(&rsaKey.PublicKey), jwt.ErrAlgUnsupported},
+		}
+		for _, tc := range testCases {
+			_, err := jwtutil.NewSSHAgent(tc.name, ag, tc.fingerprint)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwtutil.NewSSHAgent err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		}
+	})
+}
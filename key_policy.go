@@ -0,0 +1,100 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrKeyPolicy is the error for a key that doesn't meet a KeyPolicy.
+	ErrKeyPolicy = internal.NewError("jwt: key doesn't meet policy")
+	// ErrKeyType is the error for a key whose type doesn't match an algorithm.
+	ErrKeyType = internal.NewError("jwt: invalid key type for algorithm")
+)
+
+// KeyPolicy defines minimum key strength requirements.
+type KeyPolicy struct {
+	// MinRSABits is the minimum RSA modulus size, in bits.
+	MinRSABits int
+	// MinHMACKeySize is the minimum HMAC key size, in bytes.
+	MinHMACKeySize int
+	// HMACHashSize requires HMAC keys to be at least as long as the hash output.
+	HMACHashSize bool
+}
+
+// RFC7518KeyPolicy returns the KeyPolicy required by the RFC 7518, which NewHMACSHA and NewRSASHA
+// enforce unless another one is set with HMACKeyPolicy or RSAKeyPolicy. Constructors that panic,
+// like NewHS256, don't enforce any policy.
+func RFC7518KeyPolicy() KeyPolicy {
+	return KeyPolicy{
+		MinRSABits:   2048,
+		HMACHashSize: true,
+	}
+}
+
+func keyPolicyOrDefault(kp *KeyPolicy) KeyPolicy {
+	if kp == nil {
+		return RFC7518KeyPolicy()
+	}
+	return *kp
+}
+
+// Check checks whether key meets kp for the algorithm named alg. Supported keys are
+// []byte for HMAC, *rsa.PrivateKey and *rsa.PublicKey for RSA, and *ecdsa.PrivateKey
+// and *ecdsa.PublicKey for ECDSA. ECDSA keys have no strength requirements, but their curve
+// must match the algorithm, or else an error wrapping ErrECDSACurve is returned. Keys for
+// other algorithms have no requirements and are always accepted.
+func (kp KeyPolicy) Check(alg string, key interface{}) error {
+	if sha, ok := hmacHashes[alg]; ok {
+		k, ok := key.([]byte)
+		if !ok {
+			return internal.Errorf("jwt: %T for %s: %w", key, alg, ErrKeyType)
+		}
+		min := kp.MinHMACKeySize
+		if kp.HMACHashSize && sha.Size() > min {
+			min = sha.Size()
+		}
+		if len(k) < min {
+			return internal.Errorf("jwt: %d-byte HMAC key for %s, minimum is %d: %w", len(k), alg, min, ErrKeyPolicy)
+		}
+		return nil
+	}
+	if _, ok := rsaAlgs[alg]; ok {
+		var pub *rsa.PublicKey
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			pub = &k.PublicKey
+		case *rsa.PublicKey:
+			pub = k
+		default:
+			return internal.Errorf("jwt: %T for %s: %w", key, alg, ErrKeyType)
+		}
+		if pub.N == nil {
+			return ErrRSANilPubKey
+		}
+		if bits := pub.N.BitLen(); bits < kp.MinRSABits {
+			return internal.Errorf("jwt: %d-bit RSA key for %s, minimum is %d: %w", bits, alg, kp.MinRSABits, ErrKeyPolicy)
+		}
+		return nil
+	}
+	if params, ok := ecdsaAlgs[alg]; ok {
+		var pub *ecdsa.PublicKey
+		switch k := key.(type) {
+		case *ecdsa.PrivateKey:
+			pub = &k.PublicKey
+		case *ecdsa.PublicKey:
+			pub = k
+		default:
+			return internal.Errorf("jwt: %T for %s: %w", key, alg, ErrKeyType)
+		}
+		if pub.Curve == nil {
+			return ErrECDSANilPubKey
+		}
+		if want, got := params.curve().Params().Name, pub.Params().Name; got != want {
+			return internal.Errorf("jwt: %s key for %s: %w", got, alg, ErrECDSACurve)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package jwt_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKeyPolicyCheck(t *testing.T) {
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lax := jwt.KeyPolicy{MinHMACKeySize: 4}
+	testCases := []struct {
+		kp  jwt.KeyPolicy
+		alg string
+		key interface{}
+		err error
+	}{
+		{jwt.RFC7518KeyPolicy(), "HS256", bytes.Repeat(hmacKey1, 6), nil},
+		{jwt.RFC7518KeyPolicy(), "HS256", hmacKey1, jwt.ErrKeyPolicy},
+		{jwt.RFC7518KeyPolicy(), "HS256", "secret", jwt.ErrKeyType},
+		{lax, "HS512", hmacKey1, nil},
+		{lax, "HS512", []byte("key"), jwt.ErrKeyPolicy},
+		{jwt.RFC7518KeyPolicy(), "RS256", rsaPrivateKey1, nil},
+		{jwt.RFC7518KeyPolicy(), "PS384", rsaPublicKey1, nil},
+		{jwt.RFC7518KeyPolicy(), "RS256", smallKey, jwt.ErrKeyPolicy},
+		{jwt.RFC7518KeyPolicy(), "RS256", es256PrivateKey1, jwt.ErrKeyType},
+		{lax, "RS256", &smallKey.PublicKey, nil},
+		{jwt.RFC7518KeyPolicy(), "ES256", es256PrivateKey1, nil},
+		{jwt.RFC7518KeyPolicy(), "ES512", es512PublicKey1, nil},
+		{jwt.RFC7518KeyPolicy(), "ES384", es256PublicKey1, jwt.ErrECDSACurve},
+		{jwt.RFC7518KeyPolicy(), "ES256", es512PrivateKey1, jwt.ErrECDSACurve},
+		{jwt.RFC7518KeyPolicy(), "ES256", rsaPublicKey1, jwt.ErrKeyType},
+		{lax, "ES384", es256PublicKey1, jwt.ErrECDSACurve},
+		{jwt.RFC7518KeyPolicy(), "none", nil, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alg, func(t *testing.T) {
+			err := tc.kp.Check(tc.alg, tc.key)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.KeyPolicy.Check err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestKeyPolicyOptions(t *testing.T) {
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lax := jwt.KeyPolicy{MinRSABits: 1024}
+
+	if _, err = jwt.NewHMACSHA("HS256", hmacKey1); !internal.ErrorIs(err, jwt.ErrKeyPolicy) {
+		t.Fatalf("want %v, got %v", jwt.ErrKeyPolicy, err)
+	}
+	if _, err = jwt.NewHMACSHA("HS256", hmacKey1, jwt.HMACKeyPolicy(lax)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = jwt.NewRSASHA("RS256", jwt.RSAPrivateKey(smallKey)); !internal.ErrorIs(err, jwt.ErrKeyPolicy) {
+		t.Fatalf("want %v, got %v", jwt.ErrKeyPolicy, err)
+	}
+	if _, err = jwt.NewRSASHA("RS256", jwt.RSAPrivateKey(smallKey), jwt.RSAKeyPolicy(lax)); err != nil {
+		t.Fatal(err)
+	}
+	// Options don't change the default policy for other constructors.
+	if _, err = jwt.NewHMACSHA("HS256", hmacKey1); !internal.ErrorIs(err, jwt.ErrKeyPolicy) {
+		t.Fatalf("want %v, got %v", jwt.ErrKeyPolicy, err)
+	}
+}
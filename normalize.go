@@ -0,0 +1,79 @@
+package jwt
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Normalization is a set of fixes applied to a malformed token by a lenient Verifier.
+type Normalization uint8
+
+const (
+	// NormalizedBearer indicates an "Authorization" header's "Bearer" scheme was removed.
+	NormalizedBearer Normalization = 1 << iota
+	// NormalizedSpace indicates surrounding whitespace was removed.
+	NormalizedSpace
+	// NormalizedPadding indicates Base64 padding was removed.
+	NormalizedPadding
+	// NormalizedAlphabet indicates standard Base64 characters were replaced by URL-safe ones.
+	NormalizedAlphabet
+)
+
+var normalizationNames = []string{"bearer", "space", "padding", "alphabet"}
+
+// String returns a list of the applied fixes separated by "|".
+func (n Normalization) String() string {
+	if n == 0 {
+		return "none"
+	}
+	var names []string
+	for i, name := range normalizationNames {
+		if n&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// ReportNormalization sets n to the fixes applied to the token by a lenient Verifier.
+func ReportNormalization(n *Normalization) VerifyOption {
+	return func(rt *RawToken) error {
+		*n = rt.norm
+		return nil
+	}
+}
+
+var bearerPrefix = []byte("bearer ")
+
+// normalize returns the canonical form of token and which fixes were needed.
+// The token is only copied when Base64 characters need to be changed.
+func normalize(token []byte) ([]byte, Normalization) {
+	var n Normalization
+	if trimmed := bytes.TrimSpace(token); len(trimmed) != len(token) {
+		token = trimmed
+		n |= NormalizedSpace
+	}
+	if len(token) >= len(bearerPrefix) && bytes.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+		token = bytes.TrimSpace(token[len(bearerPrefix):])
+		n |= NormalizedBearer
+	}
+	if bytes.IndexAny(token, "=+/") < 0 {
+		return token, n
+	}
+	canonical := make([]byte, 0, len(token))
+	for _, c := range token {
+		switch c {
+		case '=':
+			n |= NormalizedPadding
+			continue
+		case '+':
+			n |= NormalizedAlphabet
+			c = '-'
+		case '/':
+			n |= NormalizedAlphabet
+			c = '_'
+		}
+		canonical = append(canonical, c)
+	}
+	return canonical, n
+}
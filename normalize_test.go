@@ -0,0 +1,91 @@
+package jwt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLenientVerifier(t *testing.T) {
+	hs256 := jwt.NewHS256([]byte("secret"))
+	// Payload chosen so that its encoding contains both '-' and '_'.
+	token, err := jwt.Sign(jwt.Claims{"sub": "???>>>"}, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical := string(token)
+	if !strings.ContainsAny(canonical, "-_") {
+		t.Fatalf("token %q has no URL-safe characters", canonical)
+	}
+	padded := func(s string) string {
+		parts := strings.Split(s, ".")
+		for i, p := range parts {
+			if r := len(p) % 4; r > 0 {
+				parts[i] = p + strings.Repeat("=", 4-r)
+			}
+		}
+		return strings.Join(parts, ".")
+	}
+	stdAlphabet := strings.NewReplacer("-", "+", "_", "/").Replace
+
+	testCases := []struct {
+		name  string
+		token string
+		norm  jwt.Normalization
+	}{
+		{"canonical", canonical, 0},
+		{"space", " \n" + canonical + "\r\n", jwt.NormalizedSpace},
+		{"bearer", "Bearer " + canonical, jwt.NormalizedBearer},
+		{"bearer lowercase", "bearer  " + canonical, jwt.NormalizedBearer},
+		{"padding", padded(canonical), jwt.NormalizedPadding},
+		{"alphabet", stdAlphabet(canonical), jwt.NormalizedAlphabet},
+		{
+			"all",
+			"\tBEARER " + stdAlphabet(padded(canonical)) + "\n",
+			jwt.NormalizedSpace | jwt.NormalizedBearer | jwt.NormalizedPadding | jwt.NormalizedAlphabet,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				pl   jwt.Payload
+				norm jwt.Normalization
+				vf   = jwt.Verifier{Lenient: true}
+			)
+			if _, err := vf.Verify([]byte(tc.token), hs256, &pl, jwt.ReportNormalization(&norm)); err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.norm, norm; got != want {
+				t.Errorf("jwt.Normalization mismatch (-want +got):\n%s", cmp.Diff(want.String(), got.String()))
+			}
+			if want, got := "???>>>", pl.Subject; got != want {
+				t.Errorf("jwt.Verifier.Verify payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if tc.norm != 0 {
+				if _, err := jwt.Verify([]byte(tc.token), hs256, &pl); err == nil {
+					t.Errorf("jwt.Verify accepted non-canonical token %q", tc.token)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizationString(t *testing.T) {
+	testCases := []struct {
+		norm jwt.Normalization
+		want string
+	}{
+		{0, "none"},
+		{jwt.NormalizedBearer, "bearer"},
+		{jwt.NormalizedSpace | jwt.NormalizedAlphabet, "space|alphabet"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			if want, got := tc.want, tc.norm.String(); got != want {
+				t.Errorf("jwt.Normalization.String mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
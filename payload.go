@@ -1,5 +1,7 @@
 package jwt
 
+import "time"
+
 // Payload is a JWT payload according to the RFC 7519.
 type Payload struct {
 	Issuer         string   `json:"iss,omitempty"`
@@ -10,3 +12,18 @@ type Payload struct {
 	IssuedAt       *Time    `json:"iat,omitempty"`
 	JWTID          string   `json:"jti,omitempty"`
 }
+
+// timeClaims returns the registered time claims that are set, keeping their sub-second precision.
+func (p Payload) timeClaims() map[string]time.Time {
+	times := make(map[string]time.Time, len(numericDateClaims))
+	for name, t := range map[string]*Time{
+		"exp": p.ExpirationTime,
+		"nbf": p.NotBefore,
+		"iat": p.IssuedAt,
+	} {
+		if t != nil {
+			times[name] = t.Time
+		}
+	}
+	return times
+}
@@ -14,11 +14,24 @@ type RawToken struct {
 	token      []byte
 	sep1, sep2 int
 
-	hd  Header
-	alg Algorithm
+	hd   Header
+	alg  Algorithm
+	vf   *Verifier
+	norm Normalization
 
-	pl  *Payload
-	vds []Validator
+	pl   *Payload
+	vds  []Validator
+	pvds []PayloadValidator
+	cvds []ClaimsValidator
+
+	replay ReplayStore
+
+	targets []interface{}
+	raw     *[]byte
+	tags    bool
+	claims  Claims // payload decoded as a map, cached for map targets
+
+	strictClaims bool
 }
 
 func (rt *RawToken) header() []byte        { return rt.token[:rt.sep1] }
@@ -33,27 +46,149 @@ func (rt *RawToken) setToken(token []byte, sep1, sep2 int) {
 }
 
 func (rt *RawToken) decode(payload interface{}) (err error) {
-	pb, err := internal.DecodeToBytes(rt.payload())
+	pb, err := rt.decodeSegment(rt.payload())
 	if err != nil {
 		return err
 	}
 	if !isJSONObject(pb) {
 		return ErrNotJSONObject
 	}
-	if err = json.Unmarshal(pb, payload); err != nil {
-		return err
+	if rt.vf.Strict {
+		if err = checkDuplicateMembers(pb); err != nil {
+			return err
+		}
+	}
+	raw := pb
+	if rt.vf.LenientTime {
+		if pb, err = unquoteTimeClaims(pb); err != nil {
+			return err
+		}
+	}
+	if rt.strictClaims {
+		if err = checkClaimTypes(pb); err != nil {
+			return err
+		}
+	}
+	codec := codecOrDefault(rt.vf.Codec)
+	if payload != nil {
+		if err = rt.unmarshal(codec, pb, payload); err != nil {
+			return err
+		}
+	}
+	for _, v := range rt.targets {
+		if err = rt.unmarshal(codec, pb, v); err != nil {
+			return err
+		}
+	}
+	if rt.raw != nil {
+		*rt.raw = raw
 	}
 	for _, vd := range rt.vds {
 		if err = vd(rt.pl); err != nil {
 			return err
 		}
 	}
+	if rt.tags {
+		if err = validateTags(payload); err != nil {
+			return err
+		}
+		for _, v := range rt.targets {
+			if err = validateTags(v); err != nil {
+				return err
+			}
+		}
+	}
+	if len(rt.pvds) > 0 && payload == nil {
+		if payload, err = rt.decodeClaims(codec, pb); err != nil {
+			return err
+		}
+	}
+	for _, vd := range rt.pvds {
+		if err = vd(payload); err != nil {
+			return err
+		}
+	}
+	if len(rt.cvds) > 0 {
+		cl, err := rt.decodeClaims(codec, pb)
+		if err != nil {
+			return err
+		}
+		for _, vd := range rt.cvds {
+			if err = vd(cl); err != nil {
+				return err
+			}
+		}
+	}
+	if rt.replay != nil {
+		cl, err := rt.decodeClaims(codec, pb)
+		if err != nil {
+			return err
+		}
+		pl, err := cl.Payload()
+		if err != nil {
+			return err
+		}
+		return ReplayValidator(rt.replay)(pl)
+	}
 	return nil
 }
 
+// unmarshal decodes pb into v. Map targets are copied from a single decoding
+// of the payload, so it is parsed only once no matter how many of them there are.
+func (rt *RawToken) unmarshal(codec Codec, pb []byte, v interface{}) error {
+	switch t := v.(type) {
+	case *Claims:
+		cl, err := rt.decodeClaims(codec, pb)
+		if err != nil {
+			return err
+		}
+		*t = cl
+		return nil
+	case *map[string]interface{}:
+		cl, err := rt.decodeClaims(codec, pb)
+		if err != nil {
+			return err
+		}
+		*t = cl
+		return nil
+	}
+	return codec.Unmarshal(pb, v)
+}
+
+// decodeClaims returns a copy of the payload decoded as Claims, decoding it on the first call.
+func (rt *RawToken) decodeClaims(codec Codec, pb []byte) (Claims, error) {
+	if rt.claims == nil {
+		var cl Claims
+		if err := codec.Unmarshal(pb, &cl); err != nil {
+			return nil, err
+		}
+		rt.claims = cl
+	}
+	cl := make(Claims, len(rt.claims))
+	for k, v := range rt.claims {
+		cl[k] = v
+	}
+	return cl, nil
+}
+
 func (rt *RawToken) decodeHeader() error {
-	if err := internal.Decode(rt.header(), &rt.hd); err != nil {
+	hb, err := rt.decodeSegment(rt.header())
+	if err != nil {
 		return err
 	}
-	return nil
+	if rt.vf.Strict {
+		if err = checkDuplicateMembers(hb); err != nil {
+			return err
+		}
+	}
+	// The header is decoded with encoding/json rather than the Codec, since options meant
+	// for payloads, such as rejecting unknown fields, would reject legitimate members.
+	return json.Unmarshal(hb, &rt.hd)
+}
+
+func (rt *RawToken) decodeSegment(enc []byte) ([]byte, error) {
+	if rt.vf.Strict {
+		return internal.DecodeToBytesStrict(enc)
+	}
+	return internal.DecodeToBytes(enc)
 }
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrReplayValidation is the error for when a "jti" claim has already been used.
+var ErrReplayValidation = internal.NewError("jwt: jti claim has already been used")
+
+// ReplayStore records token IDs in order to detect replayed tokens.
+// Implementations must be safe for concurrent use.
+type ReplayStore interface {
+	// Record stores jti until exp and reports whether it was already stored.
+	Record(jti string, exp time.Time) (bool, error)
+}
+
+// ReplayValidator validates the "jti" claim against rs, rejecting tokens whose ID has already been used.
+// Since IDs are only kept until the token expires, both "jti" and "exp" claims are required.
+//
+// When passed to ValidatePayload, it runs before struct tags and the validators set by ValidateClaims
+// and ValidateRawClaims, so IDs of tokens failing those are recorded anyway. Use ValidateReplay
+// for recording IDs only once every other validation has passed, as one-time tokens require.
+func ReplayValidator(rs ReplayStore) Validator {
+	return func(pl *Payload) error {
+		if pl.JWTID == "" {
+			return ErrJtiValidation
+		}
+		if pl.ExpirationTime == nil {
+			return ErrExpValidation
+		}
+		seen, err := rs.Record(pl.JWTID, pl.ExpirationTime.Time)
+		if err != nil {
+			return err
+		}
+		if seen {
+			return ErrReplayValidation
+		}
+		return nil
+	}
+}
+
+// ValidateReplay validates the "jti" claim against rs the same way ReplayValidator does,
+// but only after every other validator has passed, so the ID of a token rejected for
+// any other reason is not recorded and the token can still be used once.
+func ValidateReplay(rs ReplayStore) VerifyOption {
+	return func(rt *RawToken) error {
+		rt.replay = rs
+		return nil
+	}
+}
+
+// sweepInterval is the minimum interval between purges of expired IDs.
+const sweepInterval = time.Minute
+
+// MemoryReplayStore is an in-memory ReplayStore that forgets IDs once they expire.
+type MemoryReplayStore struct {
+	mu    sync.Mutex
+	ids   map[string]time.Time
+	sweep time.Time
+	now   func() time.Time
+}
+
+// NewMemoryReplayStore creates an empty in-memory ReplayStore.
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{
+		ids: make(map[string]time.Time),
+		now: time.Now,
+	}
+}
+
+// Record stores jti until exp and reports whether it was already stored and not yet expired.
+func (ms *MemoryReplayStore) Record(jti string, exp time.Time) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.record(jti, exp), nil
+}
+
+// Len returns the number of IDs currently stored.
+func (ms *MemoryReplayStore) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.purge(ms.now())
+	return len(ms.ids)
+}
+
+func (ms *MemoryReplayStore) record(jti string, exp time.Time) bool {
+	if ms.seen(jti) {
+		return true
+	}
+	ms.ids[jti] = exp
+	return false
+}
+
+// seen reports whether jti is stored and not yet expired, purging expired IDs from time to time.
+func (ms *MemoryReplayStore) seen(jti string) bool {
+	now := ms.now()
+	if now.After(ms.sweep) {
+		ms.purge(now)
+		ms.sweep = now.Add(sweepInterval)
+	}
+	prev, ok := ms.ids[jti]
+	return ok && !now.After(prev)
+}
+
+func (ms *MemoryReplayStore) purge(now time.Time) {
+	for jti, exp := range ms.ids {
+		if now.After(exp) {
+			delete(ms.ids, jti)
+		}
+	}
+}
+
+// Compile-time checks.
+var _ ReplayStore = new(MemoryReplayStore)
@@ -0,0 +1,177 @@
+package jwt
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrReplayStoreClosed is the error for using a FileReplayStore after closing it.
+var ErrReplayStoreClosed = internal.NewError("jwt: replay store is closed")
+
+// FileReplayStore is a ReplayStore that persists IDs to a file, so they survive restarts.
+//
+// Each record is appended to the file as a line. Expired records are dropped from the file
+// whenever the store is opened, and whenever most of the file's records have expired.
+type FileReplayStore struct {
+	mu    sync.Mutex
+	mem   *MemoryReplayStore
+	name  string
+	f     *os.File
+	lines int // records in the file, including expired ones
+}
+
+// replayCompactMin is the minimum number of records in a file before it is compacted.
+const replayCompactMin = 1024
+
+// OpenFileReplayStore opens the ReplayStore persisted at name, creating it if it doesn't exist.
+func OpenFileReplayStore(name string) (*FileReplayStore, error) {
+	mem := NewMemoryReplayStore()
+	if err := loadReplayFile(name, mem); err != nil {
+		return nil, err
+	}
+	if err := writeReplayFile(name, mem); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &FileReplayStore{mem: mem, name: name, f: f, lines: len(mem.ids)}, nil
+}
+
+// Record stores jti until exp and reports whether it was already stored and not yet expired.
+func (fs *FileReplayStore) Record(jti string, exp time.Time) (bool, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.f == nil {
+		return false, ErrReplayStoreClosed
+	}
+	if fs.mem.seen(jti) {
+		return true, nil
+	}
+	if err := fs.compact(); err != nil {
+		return false, err
+	}
+	// Only keep jti in memory once it is persisted, so a failed write doesn't
+	// make the token look replayed when it is presented again.
+	if _, err := fs.f.WriteString(formatReplayRecord(jti, exp)); err != nil {
+		return false, err
+	}
+	if err := fs.f.Sync(); err != nil {
+		return false, err
+	}
+	fs.mem.ids[jti] = exp
+	fs.lines++
+	return false, nil
+}
+
+// compact rewrites the file without expired records once they make up most of it.
+func (fs *FileReplayStore) compact() error {
+	if fs.lines < replayCompactMin {
+		return nil
+	}
+	fs.mem.purge(fs.mem.now())
+	if fs.lines < 2*len(fs.mem.ids) {
+		return nil
+	}
+	if err := writeReplayFile(fs.name, fs.mem); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(fs.name, os.O_WRONLY|os.O_APPEND, 0)
+	// The old file has been replaced, so it must not be written to anymore.
+	fs.f.Close()
+	fs.f = f
+	if err != nil {
+		fs.f = nil
+		return err
+	}
+	fs.lines = len(fs.mem.ids)
+	return nil
+}
+
+// Close closes the underlying file.
+func (fs *FileReplayStore) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.f == nil {
+		return ErrReplayStoreClosed
+	}
+	err := fs.f.Close()
+	fs.f = nil
+	return err
+}
+
+func formatReplayRecord(jti string, exp time.Time) string {
+	return fmt.Sprintf("%d %s\n", exp.Unix(), strconv.Quote(jti))
+}
+
+func loadReplayFile(name string, mem *MemoryReplayStore) error {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		fields := strings.SplitN(sc.Text(), " ", 2)
+		if len(fields) != 2 {
+			return internal.Errorf("jwt: %s:%d: malformed replay record", name, n)
+		}
+		unix, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return internal.Errorf("jwt: %s:%d: %w", name, n, err)
+		}
+		jti, err := strconv.Unquote(fields[1])
+		if err != nil {
+			return internal.Errorf("jwt: %s:%d: %w", name, n, err)
+		}
+		mem.record(jti, time.Unix(unix, 0))
+	}
+	return sc.Err()
+}
+
+// writeReplayFile atomically replaces name with the IDs in mem that haven't expired yet.
+func writeReplayFile(name string, mem *MemoryReplayStore) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(name), filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	mem.purge(mem.now())
+	w := bufio.NewWriter(tmp)
+	for jti, exp := range mem.ids {
+		if _, err = w.WriteString(formatReplayRecord(jti, exp)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err = w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Compile-time checks.
+var _ ReplayStore = new(FileReplayStore)
@@ -0,0 +1,214 @@
+package jwt_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReplayValidator(t *testing.T) {
+	exp := jwt.NumericDate(time.Now().Add(time.Hour))
+	testCases := []struct {
+		pl  *jwt.Payload
+		err error
+	}{
+		{&jwt.Payload{JWTID: "foo", ExpirationTime: exp}, nil},
+		{&jwt.Payload{JWTID: "bar", ExpirationTime: exp}, nil},
+		{&jwt.Payload{JWTID: "foo", ExpirationTime: exp}, jwt.ErrReplayValidation},
+		{&jwt.Payload{JWTID: "", ExpirationTime: exp}, jwt.ErrJtiValidation},
+		{&jwt.Payload{JWTID: "baz"}, jwt.ErrExpValidation},
+	}
+	vd := jwt.ReplayValidator(jwt.NewMemoryReplayStore())
+	for _, tc := range testCases {
+		t.Run(tc.pl.JWTID, func(t *testing.T) {
+			if want, got := tc.err, vd(tc.pl); !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestValidateReplay(t *testing.T) {
+	hs256 := jwt.NewHS256([]byte("secret"))
+	token, err := jwt.Sign(jwt.Payload{
+		JWTID:          "reset",
+		ExpirationTime: jwt.NumericDate(time.Now().Add(time.Hour)),
+	}, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errRule := errors.New("business rule")
+	testCases := []struct {
+		rule error
+		err  error
+	}{
+		{errRule, errRule}, // the ID is not recorded, so the token can still be used
+		{nil, nil},
+		{nil, jwt.ErrReplayValidation},
+	}
+	opt := jwt.ValidateReplay(jwt.NewMemoryReplayStore())
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			vd := func(interface{}) error { return tc.rule }
+			_, err := jwt.Verify(token, hs256, nil, opt, jwt.ValidateClaims(vd))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestMemoryReplayStore(t *testing.T) {
+	t.Run("expiration", func(t *testing.T) {
+		ms := jwt.NewMemoryReplayStore()
+		past := time.Now().Add(-time.Second)
+		for i := 0; i < 2; i++ {
+			seen, err := ms.Record("foo", past)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seen {
+				t.Errorf("expired ID reported as seen")
+			}
+		}
+		if want, got := 0, ms.Len(); got != want {
+			t.Errorf("jwt.MemoryReplayStore.Len mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		var (
+			ms    = jwt.NewMemoryReplayStore()
+			exp   = time.Now().Add(time.Hour)
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			count int
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				seen, err := ms.Record("foo", exp)
+				if err != nil {
+					t.Error(err)
+				}
+				if !seen {
+					mu.Lock()
+					count++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if want, got := 1, count; got != want {
+			t.Errorf("unseen records mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
+
+func TestFileReplayStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "replay")
+
+	fs, err := jwt.OpenFileReplayStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour)
+	for _, jti := range []string{"foo", "bar baz", "expired"} {
+		recExp := exp
+		if jti == "expired" {
+			recExp = time.Now().Add(-time.Hour)
+		}
+		if _, err = fs.Record(jti, recExp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = fs.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = fs.Record("qux", exp); !internal.ErrorIs(err, jwt.ErrReplayStoreClosed) {
+		t.Errorf("want %v, got %v", jwt.ErrReplayStoreClosed, err)
+	}
+
+	if fs, err = jwt.OpenFileReplayStore(name); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+	testCases := []struct {
+		jti  string
+		seen bool
+	}{
+		{"foo", true},
+		{"bar baz", true},
+		{"expired", false},
+		{"qux", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jti, func(t *testing.T) {
+			seen, err := fs.Record(tc.jti, exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.seen, seen; got != want {
+				t.Errorf("jwt.FileReplayStore.Record mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestFileReplayStoreCompaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "replay")
+
+	fs, err := jwt.OpenFileReplayStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+	expired := time.Now().Add(-time.Hour)
+	for i := 0; i < 3000; i++ {
+		if _, err = fs.Record(fmt.Sprint(i), expired); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = fs.Record("live", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file was compacted while open, so it holds less than the 1024 records needed for compacting.
+	if lines := bytes.Count(b, []byte("\n")); lines >= 1024 {
+		t.Errorf("want less than 1024 records, got %d", lines)
+	}
+	if !bytes.Contains(b, []byte(`"live"`)) {
+		t.Errorf("live record was dropped")
+	}
+	seen, err := fs.Record("live", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !seen {
+		t.Errorf("live record was forgotten")
+	}
+}
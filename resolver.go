@@ -1,7 +1,31 @@
 package jwt
 
+import "context"
+
 // Resolver is an Algorithm that needs to set some variables
 // based on a Header before performing signing and verification.
 type Resolver interface {
 	Resolve(Header) error
 }
+
+// ContextResolver is a Resolver whose resolution may do I/O, such as fetching keys,
+// so it also accepts a context for deadlines and cancellation.
+type ContextResolver interface {
+	Resolver
+	ResolveContext(context.Context, Header) error
+}
+
+// resolve resolves alg if it is a Resolver, preferring ResolveContext when available.
+func resolve(ctx context.Context, alg Algorithm, hd Header) error {
+	if rv, ok := alg.(ContextResolver); ok {
+		return rv.ResolveContext(ctx, hd)
+	}
+	rv, ok := alg.(Resolver)
+	if !ok {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return rv.Resolve(hd)
+}
@@ -0,0 +1,189 @@
+package jwt
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrRevoked is the error for when a token has been revoked.
+var ErrRevoked = internal.NewError("jwt: token has been revoked")
+
+// RevocationStore keeps track of revoked tokens.
+// Implementations must be safe for concurrent use.
+//
+// Every revocation carries an expiration time after which it may be
+// forgotten, usually the "exp" claim of the revoked token or, for subjects,
+// the maximum lifetime of issued tokens.
+type RevocationStore interface {
+	// RevokeID revokes the token identified by jti until exp.
+	RevokeID(jti string, exp time.Time) error
+	// RevokeSubject revokes every token for sub issued before the given time until exp.
+	// Since "iat" usually has a precision of seconds, tokens issued within the same
+	// second as before should be revoked as well, as they may have been issued before it.
+	RevokeSubject(sub string, before, exp time.Time) error
+	// RevokeToken revokes the token whose TokenHash is sum until exp.
+	RevokeToken(sum []byte, exp time.Time) error
+
+	// IDRevoked reports whether jti has been revoked.
+	IDRevoked(jti string) (bool, error)
+	// SubjectRevoked reports whether tokens for sub issued at iat have been revoked.
+	SubjectRevoked(sub string, iat time.Time) (bool, error)
+	// TokenRevoked reports whether the token whose TokenHash is sum has been revoked.
+	TokenRevoked(sum []byte) (bool, error)
+}
+
+// TokenHash returns the SHA-256 hash of a token, used for revoking tokens that don't have a "jti" claim.
+func TokenHash(token []byte) []byte {
+	sum := sha256.Sum256(token)
+	return sum[:]
+}
+
+// RevocationValidator validates the "jti" and "sub" claims against rs.
+// Tokens without an "iat" claim are considered revoked whenever their subject is.
+func RevocationValidator(rs RevocationStore) Validator {
+	return func(pl *Payload) error {
+		if pl.JWTID != "" {
+			revoked, err := rs.IDRevoked(pl.JWTID)
+			if err != nil {
+				return err
+			}
+			if revoked {
+				return ErrRevoked
+			}
+		}
+		if pl.Subject != "" {
+			var iat time.Time
+			if pl.IssuedAt != nil {
+				iat = pl.IssuedAt.Time
+			}
+			revoked, err := rs.SubjectRevoked(pl.Subject, iat)
+			if err != nil {
+				return err
+			}
+			if revoked {
+				return ErrRevoked
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateRevocation checks whether the token's hash has been revoked in rs.
+// In order to check the "jti" and "sub" claims as well, use RevocationValidator.
+func ValidateRevocation(rs RevocationStore) VerifyOption {
+	return func(rt *RawToken) error {
+		revoked, err := rs.TokenRevoked(TokenHash(rt.token))
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return ErrRevoked
+		}
+		return nil
+	}
+}
+
+type revocation struct {
+	before time.Time
+	exp    time.Time
+}
+
+// MemoryRevocationStore is an in-memory RevocationStore that forgets revocations once they expire.
+type MemoryRevocationStore struct {
+	mu     sync.RWMutex
+	ids    map[string]revocation
+	subs   map[string]revocation
+	tokens map[string]revocation
+	sweep  time.Time
+	now    func() time.Time
+}
+
+// NewMemoryRevocationStore creates an empty in-memory RevocationStore.
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		ids:    make(map[string]revocation),
+		subs:   make(map[string]revocation),
+		tokens: make(map[string]revocation),
+		now:    time.Now,
+	}
+}
+
+// RevokeID revokes the token identified by jti until exp.
+func (ms *MemoryRevocationStore) RevokeID(jti string, exp time.Time) error {
+	ms.revoke(ms.ids, jti, revocation{exp: exp})
+	return nil
+}
+
+// RevokeSubject revokes every token for sub issued before the given time until exp,
+// including tokens issued within the same second as before.
+func (ms *MemoryRevocationStore) RevokeSubject(sub string, before, exp time.Time) error {
+	ms.revoke(ms.subs, sub, revocation{before: before, exp: exp})
+	return nil
+}
+
+// RevokeToken revokes the token whose TokenHash is sum until exp.
+func (ms *MemoryRevocationStore) RevokeToken(sum []byte, exp time.Time) error {
+	ms.revoke(ms.tokens, string(sum), revocation{exp: exp})
+	return nil
+}
+
+// IDRevoked reports whether jti has been revoked.
+func (ms *MemoryRevocationStore) IDRevoked(jti string) (bool, error) {
+	_, ok := ms.lookup(ms.ids, jti)
+	return ok, nil
+}
+
+// SubjectRevoked reports whether tokens for sub issued at iat have been revoked.
+func (ms *MemoryRevocationStore) SubjectRevoked(sub string, iat time.Time) (bool, error) {
+	rv, ok := ms.lookup(ms.subs, sub)
+	// Compare whole seconds, since that is the precision of "iat".
+	return ok && !iat.Truncate(time.Second).After(rv.before.Truncate(time.Second)), nil
+}
+
+// TokenRevoked reports whether the token whose TokenHash is sum has been revoked.
+func (ms *MemoryRevocationStore) TokenRevoked(sum []byte) (bool, error) {
+	_, ok := ms.lookup(ms.tokens, string(sum))
+	return ok, nil
+}
+
+func (ms *MemoryRevocationStore) revoke(m map[string]revocation, k string, rv revocation) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	now := ms.now()
+	if now.After(ms.sweep) {
+		for _, m := range []map[string]revocation{ms.ids, ms.subs, ms.tokens} {
+			for k, rv := range m {
+				if now.After(rv.exp) {
+					delete(m, k)
+				}
+			}
+		}
+		ms.sweep = now.Add(sweepInterval)
+	}
+	if prev, ok := m[k]; ok {
+		// Keep the widest revocation.
+		if prev.before.After(rv.before) {
+			rv.before = prev.before
+		}
+		if prev.exp.After(rv.exp) {
+			rv.exp = prev.exp
+		}
+	}
+	m[k] = rv
+}
+
+func (ms *MemoryRevocationStore) lookup(m map[string]revocation, k string) (revocation, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	rv, ok := m[k]
+	if !ok || ms.now().After(rv.exp) {
+		return revocation{}, false
+	}
+	return rv, true
+}
+
+// Compile-time checks.
+var _ RevocationStore = new(MemoryRevocationStore)
@@ -0,0 +1,88 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRevocationValidator(t *testing.T) {
+	now := time.Now()
+	exp := now.Add(time.Hour)
+	rs := jwt.NewMemoryRevocationStore()
+	if err := rs.RevokeID("revoked", exp); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.RevokeID("expired", now.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.RevokeSubject("someone", now, exp); err != nil {
+		t.Fatal(err)
+	}
+	// Revoked halfway through a second, so tokens issued earlier in the same second have an "iat" before it.
+	boundary := time.Unix(now.Unix(), int64(500*time.Millisecond))
+	if err := rs.RevokeSubject("boundary", boundary, exp); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		pl  *jwt.Payload
+		err error
+	}{
+		{&jwt.Payload{JWTID: "foo"}, nil},
+		{&jwt.Payload{JWTID: "revoked"}, jwt.ErrRevoked},
+		{&jwt.Payload{JWTID: "expired"}, nil},
+		{&jwt.Payload{Subject: "someone", IssuedAt: jwt.NumericDate(now.Add(-time.Minute))}, jwt.ErrRevoked},
+		{&jwt.Payload{Subject: "someone", IssuedAt: jwt.NumericDate(now.Add(time.Minute))}, nil},
+		{&jwt.Payload{Subject: "someone"}, jwt.ErrRevoked},
+		{&jwt.Payload{Subject: "someone else"}, nil},
+		{&jwt.Payload{Subject: "boundary", IssuedAt: jwt.NumericDate(boundary.Add(-100 * time.Millisecond))}, jwt.ErrRevoked},
+		{&jwt.Payload{Subject: "boundary", IssuedAt: &jwt.Time{Time: boundary.Truncate(time.Second)}}, jwt.ErrRevoked},
+		{&jwt.Payload{Subject: "boundary", IssuedAt: jwt.NumericDate(boundary.Add(time.Second))}, nil},
+	}
+	vd := jwt.RevocationValidator(rs)
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			if want, got := tc.err, vd(tc.pl); !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestValidateRevocation(t *testing.T) {
+	var (
+		hs256 = jwt.NewHS256([]byte("secret"))
+		rs    = jwt.NewMemoryRevocationStore()
+		exp   = time.Now().Add(time.Hour)
+	)
+	token, err := jwt.Sign(jwt.Payload{Subject: "foo"}, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := jwt.Sign(jwt.Payload{Subject: "bar"}, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rs.RevokeToken(jwt.TokenHash(token), exp); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		token []byte
+		err   error
+	}{
+		{token, jwt.ErrRevoked},
+		{other, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.token), func(t *testing.T) {
+			var pl jwt.Payload
+			_, err := jwt.Verify(tc.token, hs256, &pl, jwt.ValidateRevocation(rs))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"io"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -15,6 +16,8 @@ var (
 	ErrRSANilPubKey = internal.NewError("jwt: RSA public key is nil")
 	// ErrRSAVerification is the error for an invalid RSA signature.
 	ErrRSAVerification = internal.NewError("jwt: RSA verification failed")
+	// ErrRSAKeyTooSmall is the error for an RSA key too small to be used with an algorithm.
+	ErrRSAKeyTooSmall = internal.NewError("jwt: RSA key is too small")
 
 	_ Algorithm = new(RSASHA)
 )
@@ -33,6 +36,23 @@ func RSAPublicKey(pub *rsa.PublicKey) func(*RSASHA) {
 	}
 }
 
+// RSARandom is an option to set the source of randomness used for RSA blinding and PSS salts,
+// which is crypto/rand.Reader by default. A deterministic reader makes PSS signatures reproducible,
+// which is useful for golden-file tests, but must never be used in production.
+func RSARandom(r io.Reader) func(*RSASHA) {
+	return func(rs *RSASHA) {
+		rs.rand = r
+	}
+}
+
+// RSAKeyPolicy is an option to set the KeyPolicy NewRSASHA checks the key against,
+// instead of RFC7518KeyPolicy.
+func RSAKeyPolicy(kp KeyPolicy) func(*RSASHA) {
+	return func(rs *RSASHA) {
+		rs.policy = &kp
+	}
+}
+
 // RSASHA is an algorithm that uses RSA to sign SHA hashes.
 type RSASHA struct {
 	name string
@@ -42,9 +62,26 @@ type RSASHA struct {
 	size int
 	pool *hashPool
 	opts *rsa.PSSOptions
+	rand io.Reader
+
+	policy *KeyPolicy
+}
+
+type rsaParams struct {
+	sha crypto.Hash
+	pss bool
+}
+
+var rsaAlgs = map[string]rsaParams{
+	"RS256": {crypto.SHA256, false},
+	"RS384": {crypto.SHA384, false},
+	"RS512": {crypto.SHA512, false},
+	"PS256": {crypto.SHA256, true},
+	"PS384": {crypto.SHA384, true},
+	"PS512": {crypto.SHA512, true},
 }
 
-func newRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) *RSASHA {
+func newRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) (*RSASHA, error) {
 	rs := RSASHA{
 		name: name, // cache name
 		sha:  sha,
@@ -57,10 +94,13 @@ func newRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) *RS
 	}
 	if rs.pub == nil {
 		if rs.priv == nil {
-			panic(ErrRSANilPrivKey)
+			return nil, ErrRSANilPrivKey
 		}
 		rs.pub = &rs.priv.PublicKey
 	}
+	if rs.pub.N == nil {
+		return nil, ErrRSANilPubKey
+	}
 	rs.size = rs.pub.Size() // cache size
 	if pss {
 		rs.opts = &rsa.PSSOptions{
@@ -68,37 +108,72 @@ func newRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) *RS
 			Hash:       sha,
 		}
 	}
-	return &rs
+	return &rs, nil
+}
+
+func mustRSASHA(rs *RSASHA, err error) *RSASHA {
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+// NewRSASHA creates a new RSA-SHA algorithm named name, which must be one of "RS256", "RS384",
+// "RS512", "PS256", "PS384" or "PS512". Unlike NewRS256 and its siblings, it returns an error
+// instead of panicking, and it also checks the key's modulus is large enough for the algorithm
+// and meets RFC7518KeyPolicy, or the policy set with RSAKeyPolicy.
+func NewRSASHA(name string, opts ...func(*RSASHA)) (*RSASHA, error) {
+	params, ok := rsaAlgs[name]
+	if !ok {
+		return nil, internal.Errorf("jwt: %q: %w", name, ErrAlgUnsupported)
+	}
+	rs, err := newRSASHA(name, opts, params.sha, params.pss)
+	if err != nil {
+		return nil, err
+	}
+	// PKCS #1 v1.5 needs room for a 19-byte DigestInfo prefix and 11 bytes of padding,
+	// while PSS needs room for a salt as long as the hash plus 2 bytes.
+	minSize := 19 + params.sha.Size() + 11
+	if params.pss {
+		minSize = 2*params.sha.Size() + 2
+	}
+	if rs.pub.Size() < minSize {
+		return nil, internal.Errorf("jwt: %d-bit key for %s: %w", rs.pub.N.BitLen(), name, ErrRSAKeyTooSmall)
+	}
+	if err = keyPolicyOrDefault(rs.policy).Check(name, rs.pub); err != nil {
+		return nil, err
+	}
+	return rs, nil
 }
 
 // NewRS256 creates a new algorithm using RSA and SHA-256.
 func NewRS256(opts ...func(*RSASHA)) *RSASHA {
-	return newRSASHA("RS256", opts, crypto.SHA256, false)
+	return mustRSASHA(newRSASHA("RS256", opts, crypto.SHA256, false))
 }
 
 // NewRS384 creates a new algorithm using RSA and SHA-384.
 func NewRS384(opts ...func(*RSASHA)) *RSASHA {
-	return newRSASHA("RS384", opts, crypto.SHA384, false)
+	return mustRSASHA(newRSASHA("RS384", opts, crypto.SHA384, false))
 }
 
 // NewRS512 creates a new algorithm using RSA and SHA-512.
 func NewRS512(opts ...func(*RSASHA)) *RSASHA {
-	return newRSASHA("RS512", opts, crypto.SHA512, false)
+	return mustRSASHA(newRSASHA("RS512", opts, crypto.SHA512, false))
 }
 
 // NewPS256 creates a new algorithm using RSA-PSS and SHA-256.
 func NewPS256(opts ...func(*RSASHA)) *RSASHA {
-	return newRSASHA("PS256", opts, crypto.SHA256, true)
+	return mustRSASHA(newRSASHA("PS256", opts, crypto.SHA256, true))
 }
 
 // NewPS384 creates a new algorithm using RSA-PSS and SHA-384.
 func NewPS384(opts ...func(*RSASHA)) *RSASHA {
-	return newRSASHA("PS384", opts, crypto.SHA384, true)
+	return mustRSASHA(newRSASHA("PS384", opts, crypto.SHA384, true))
 }
 
 // NewPS512 creates a new algorithm using RSA-PSS and SHA-512.
 func NewPS512(opts ...func(*RSASHA)) *RSASHA {
-	return newRSASHA("PS512", opts, crypto.SHA512, true)
+	return mustRSASHA(newRSASHA("PS512", opts, crypto.SHA512, true))
 }
 
 // Name returns the algorithm's name.
@@ -115,10 +190,19 @@ func (rs *RSASHA) Sign(headerPayload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	random := rs.rand
+	if random == nil {
+		random = rand.Reader
+	}
 	if rs.opts != nil {
-		return rsa.SignPSS(rand.Reader, rs.priv, rs.sha, sum, rs.opts)
+		return rsa.SignPSS(random, rs.priv, rs.sha, sum, rs.opts)
 	}
-	return rsa.SignPKCS1v15(rand.Reader, rs.priv, rs.sha, sum)
+	return rsa.SignPKCS1v15(random, rs.priv, rs.sha, sum)
+}
+
+// Public returns the RSA public key, which is an *rsa.PublicKey.
+func (rs *RSASHA) Public() crypto.PublicKey {
+	return rs.pub
 }
 
 // Size returns the signature's byte size.
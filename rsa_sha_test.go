@@ -1,9 +1,11 @@
 package jwt_test
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
-
+	"encoding/base64"
+	"io"
 	"testing"
 
 	"github.com/gbrlsnchs/jwt/v3"
@@ -465,3 +467,75 @@ func genRSAKeys() (*rsa.PrivateKey, *rsa.PublicKey) {
 	}
 	return priv, &priv.PublicKey
 }
+
+func TestNewRSASHAByName(t *testing.T) {
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name string
+		opts []func(*jwt.RSASHA)
+		err  error
+	}{
+		{"RS256", []func(*jwt.RSASHA){jwt.RSAPrivateKey(rsaPrivateKey1)}, nil},
+		{"PS512", []func(*jwt.RSASHA){jwt.RSAPublicKey(rsaPublicKey1)}, nil},
+		{"RS512", []func(*jwt.RSASHA){jwt.RSAPrivateKey(smallKey)}, jwt.ErrKeyPolicy},
+		{"PS512", []func(*jwt.RSASHA){jwt.RSAPrivateKey(smallKey)}, jwt.ErrRSAKeyTooSmall},
+		{"RS256", nil, jwt.ErrRSANilPrivKey},
+		{"RS256", []func(*jwt.RSASHA){jwt.RSAPublicKey(&rsa.PublicKey{})}, jwt.ErrRSANilPubKey},
+		{"ES256", []func(*jwt.RSASHA){jwt.RSAPrivateKey(rsaPrivateKey1)}, jwt.ErrAlgUnsupported},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := jwt.NewRSASHA(tc.name, tc.opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.NewRSASHA err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err == nil && rs.Name() != tc.name {
+				t.Errorf("want %s, got %s", tc.name, rs.Name())
+			}
+		})
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
+
+func TestRSARandom(t *testing.T) {
+	testCases := []struct {
+		builder func(...func(*jwt.RSASHA)) *jwt.RSASHA
+		random  io.Reader
+		same    bool
+	}{
+		{jwt.NewPS256, zeroReader{}, true},
+		{jwt.NewPS512, zeroReader{}, true},
+		{jwt.NewPS256, nil, false},
+		{jwt.NewRS256, nil, true},
+	}
+	for _, tc := range testCases {
+		t.Run(funcName(tc.builder), func(t *testing.T) {
+			rs := tc.builder(jwt.RSAPrivateKey(rsaPrivateKey1), jwt.RSARandom(tc.random))
+			sig1, err := rs.Sign([]byte("header.payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig2, err := rs.Sign([]byte("header.payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.same, bytes.Equal(sig1, sig2); got != want {
+				t.Errorf("want equal signatures to be %t, got %t", want, got)
+			}
+			if err = rs.Verify([]byte("header.payload"), []byte(base64.RawURLEncoding.EncodeToString(sig2))); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
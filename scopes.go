@@ -0,0 +1,110 @@
+package jwt
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrScopeValidation is the error for when required scopes are missing.
+var ErrScopeValidation = internal.NewError("jwt: scope claim is invalid")
+
+// Scopes is a special claim that may either be a space-delimited string,
+// such as "scope" from the RFC 8693, or an array of strings, such as "scp",
+// "roles" or "permissions".
+//
+// It is always marshaled as a space-delimited string, as the RFC 8693 and the RFC 9068
+// require for "scope". Claims meant to be arrays should use ScopeList instead.
+type Scopes []string
+
+// MarshalJSON implements a marshaling function for scope claims.
+func (s Scopes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(s, " "))
+}
+
+// ScopeList is the same as Scopes, except it is always marshaled as an array of strings,
+// for claims such as "scp", "roles" or "permissions". It can be converted to *Scopes
+// for the scope validators, as in jwt.AllScopesValidator((*jwt.Scopes)(&pl.Roles), "admin").
+type ScopeList []string
+
+// UnmarshalJSON implements an unmarshaling function for scope list claims,
+// which accepts space-delimited strings as well.
+func (s *ScopeList) UnmarshalJSON(b []byte) error {
+	return (*Scopes)(s).UnmarshalJSON(b)
+}
+
+// UnmarshalJSON implements an unmarshaling function for scope claims.
+func (s *Scopes) UnmarshalJSON(b []byte) error {
+	var (
+		v   interface{}
+		err error
+	)
+	if err = json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch vv := v.(type) {
+	case nil:
+		*s = nil
+	case string:
+		*s = strings.Fields(vv)
+	case []interface{}:
+		sc := make(Scopes, len(vv))
+		for i := range vv {
+			str, ok := vv[i].(string)
+			if !ok {
+				return internal.Errorf("jwt: scopes: %w", ErrClaimType)
+			}
+			sc[i] = str
+		}
+		*s = sc
+	default:
+		return internal.Errorf("jwt: scopes: %w", ErrClaimType)
+	}
+	return nil
+}
+
+// Contains reports whether scope is listed in s.
+func (s Scopes) Contains(scope string) bool {
+	for _, sc := range s {
+		if sc == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns which scopes from required are not listed in s.
+func (s Scopes) Missing(required ...string) []string {
+	var missing []string
+	for _, sc := range required {
+		if !s.Contains(sc) {
+			missing = append(missing, sc)
+		}
+	}
+	return missing
+}
+
+// AllScopesValidator validates that every scope in required is listed in sc.
+// As with ValidatePayload, sc is usually a field of the payload passed to Verify.
+func AllScopesValidator(sc *Scopes, required ...string) PayloadValidator {
+	return func(_ interface{}) error {
+		if missing := sc.Missing(required...); len(missing) > 0 {
+			return internal.Errorf("jwt: missing scopes %q: %w", missing, ErrScopeValidation)
+		}
+		return nil
+	}
+}
+
+// AnyScopeValidator validates that at least one scope in accepted is listed in sc.
+// As with ValidatePayload, sc is usually a field of the payload passed to Verify.
+func AnyScopeValidator(sc *Scopes, accepted ...string) PayloadValidator {
+	return func(_ interface{}) error {
+		for _, scope := range accepted {
+			if sc.Contains(scope) {
+				return nil
+			}
+		}
+		return internal.Errorf("jwt: missing any of scopes %q: %w", accepted, ErrScopeValidation)
+	}
+}
@@ -0,0 +1,149 @@
+package jwt_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScopesMarshal(t *testing.T) {
+	testCases := []struct {
+		sc   jwt.Scopes
+		want string
+	}{
+		{nil, `""`},
+		{jwt.Scopes{"read"}, `"read"`},
+		{jwt.Scopes{"read", "write"}, `"read write"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			b, err := json.Marshal(tc.sc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.want, string(b); got != want {
+				t.Errorf("jwt.Scopes.Marshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestScopesRoundTrip(t *testing.T) {
+	testCases := []struct {
+		json      string
+		scopes    string
+		scopeList string
+	}{
+		{`""`, `""`, `[]`},
+		{`"read"`, `"read"`, `["read"]`},
+		{`"read write"`, `"read write"`, `["read","write"]`},
+		{`["read","write"]`, `"read write"`, `["read","write"]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			var (
+				sc jwt.Scopes
+				sl jwt.ScopeList
+			)
+			for _, v := range []interface{}{&sc, &sl} {
+				if err := json.Unmarshal([]byte(tc.json), v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, c := range []struct {
+				v    interface{}
+				want string
+			}{{sc, tc.scopes}, {sl, tc.scopeList}} {
+				b, err := json.Marshal(c.v)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want, got := c.want, string(b); got != want {
+					t.Errorf("%T round trip mismatch (-want +got):\n%s", c.v, cmp.Diff(want, got))
+				}
+			}
+		})
+	}
+}
+
+func TestScopesUnmarshal(t *testing.T) {
+	testCases := []struct {
+		json string
+		want jwt.Scopes
+		err  error
+	}{
+		{`null`, nil, nil},
+		{`""`, jwt.Scopes{}, nil},
+		{`"read"`, jwt.Scopes{"read"}, nil},
+		{`"read  write\tdelete"`, jwt.Scopes{"read", "write", "delete"}, nil},
+		{`["read","write"]`, jwt.Scopes{"read", "write"}, nil},
+		{`["read",1]`, nil, jwt.ErrClaimType},
+		{`1`, nil, jwt.ErrClaimType},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			var sc jwt.Scopes
+			err := json.Unmarshal([]byte(tc.json), &sc)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.Scopes.Unmarshal error mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.want, sc; !cmp.Equal(got, want) {
+				t.Errorf("jwt.Scopes.Unmarshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+type scopedPayload struct {
+	jwt.Payload
+	Scope jwt.Scopes    `json:"scope,omitempty"`
+	SCP   jwt.ScopeList `json:"scp,omitempty"`
+}
+
+func TestScopesValidators(t *testing.T) {
+	hs256 := jwt.NewHS256([]byte("secret"))
+	token, err := jwt.Sign(map[string]interface{}{
+		"scope": "read write",
+		"scp":   []string{"admin"},
+	}, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		scp     bool
+		all     bool
+		scopes  []string
+		err     error
+		missing string
+	}{
+		{false, true, []string{"read", "write"}, nil, ""},
+		{false, true, []string{"read", "delete", "admin"}, jwt.ErrScopeValidation, `["delete" "admin"]`},
+		{true, true, []string{"admin"}, nil, ""},
+		{false, false, []string{"delete", "write"}, nil, ""},
+		{true, false, []string{"read", "write"}, jwt.ErrScopeValidation, `["read" "write"]`},
+	}
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			var pl scopedPayload
+			sc := &pl.Scope
+			if tc.scp {
+				sc = (*jwt.Scopes)(&pl.SCP)
+			}
+			vd := jwt.AnyScopeValidator(sc, tc.scopes...)
+			if tc.all {
+				vd = jwt.AllScopesValidator(sc, tc.scopes...)
+			}
+			_, err := jwt.Verify(token, hs256, &pl, jwt.ValidateClaims(vd))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf(cmp.Diff(want, got))
+			}
+			if err != nil && !strings.Contains(err.Error(), tc.missing) {
+				t.Errorf("error %q doesn't list missing scopes %s", err, tc.missing)
+			}
+		})
+	}
+}
@@ -1,8 +1,9 @@
 package jwt
 
 import (
+	"context"
 	"encoding/base64"
-	"encoding/json"
+	"time"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -24,22 +25,51 @@ func KeyID(kid string) SignOption {
 	}
 }
 
+// Signer signs tokens using a custom Codec. Its zero value behaves exactly like Sign.
+type Signer struct {
+	// Codec marshals headers and payloads. If nil, DefaultCodec is used.
+	Codec Codec
+
+	// TimePrecision is the precision of the "exp", "nbf" and "iat" claims when they are
+	// set by a Payload or by Claims. Sub-second precisions produce fractional NumericDates,
+	// as allowed by the RFC 7519. The default, one second, produces integers.
+	// Note that NumericDate truncates times to seconds, so set sub-second times directly.
+	TimePrecision time.Duration
+}
+
+var defaultSigner Signer
+
 // Sign signs a payload with alg.
 func Sign(payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error) {
+	return defaultSigner.SignContext(context.Background(), payload, alg, opts...)
+}
+
+// SignContext signs a payload with alg the same way Sign does, but passing ctx to alg
+// if it is a ContextAlgorithm or a ContextResolver, so remote signing can be canceled.
+func SignContext(ctx context.Context, payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error) {
+	return defaultSigner.SignContext(ctx, payload, alg, opts...)
+}
+
+// Sign signs a payload with alg the same way Sign does, but using sg's Codec.
+func (sg *Signer) Sign(payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error) {
+	return sg.SignContext(context.Background(), payload, alg, opts...)
+}
+
+// SignContext signs a payload with alg the same way SignContext does, but using sg's Codec.
+func (sg *Signer) SignContext(ctx context.Context, payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error) {
+	codec := codecOrDefault(sg.Codec)
 	var hd Header
 	for _, opt := range opts {
 		opt(&hd)
 	}
-	if rv, ok := alg.(Resolver); ok {
-		if err := rv.Resolve(hd); err != nil {
-			return nil, internal.Errorf("jwt: failed to resolve: %w", err)
-		}
+	if err := resolve(ctx, alg, hd); err != nil {
+		return nil, internal.Errorf("jwt: failed to resolve: %w", err)
 	}
 	// Override some values or set them if empty.
 	hd.Algorithm = alg.Name()
 	hd.Type = "JWT"
 	// Marshal the header part of the JWT.
-	hb, err := json.Marshal(hd)
+	hb, err := codec.Marshal(hd)
 	if err != nil {
 		return nil, err
 	}
@@ -48,13 +78,18 @@ func Sign(payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error
 		payload = Payload{}
 	}
 	// Marshal the claims part of the JWT.
-	pb, err := json.Marshal(payload)
+	pb, err := codec.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 	if !isJSONObject(pb) {
 		return nil, ErrNotJSONObject
 	}
+	if sg.TimePrecision > 0 && sg.TimePrecision < time.Second {
+		if pb, err = preciseTimeClaims(pb, payload, sg.TimePrecision); err != nil {
+			return nil, err
+		}
+	}
 
 	enc := base64.RawURLEncoding
 	h64len := enc.EncodedLen(len(hb))
@@ -65,7 +100,7 @@ func Sign(payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error
 	enc.Encode(token, hb)
 	token[h64len] = '.'
 	enc.Encode(token[h64len+1:], pb)
-	sig, err := alg.Sign(token[:h64len+1+p64len])
+	sig, err := WithContext(alg).SignContext(ctx, token[:h64len+1+p64len])
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// StrictClaims enables strict decoding of registered claims. Before decoding
+// the payload, registered claims are checked against the types defined by the
+// RFC 7519, and an error wrapping ErrClaimType is returned in case of a mismatch:
+//   - "iss", "sub" and "jti" must be strings
+//   - "aud" must be either a string or an array of strings
+//   - "exp", "nbf" and "iat" must be non-negative numbers
+//
+// Null values are also rejected, instead of being silently ignored. As when decoding,
+// names are matched case-insensitively, so "EXP" is checked the same as "exp".
+func StrictClaims(rt *RawToken) error {
+	rt.strictClaims = true
+	return nil
+}
+
+func checkClaimTypes(payload []byte) error {
+	var claims map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return err
+	}
+	for name, raw := range claims {
+		var ok bool
+		// Names are folded, since encoding/json matches them to Payload's fields case-insensitively.
+		switch foldName(name) {
+		case "ISS", "SUB", "JTI":
+			ok = isJSONString(raw)
+		case "AUD":
+			ok = isJSONString(raw) || isJSONStringArray(raw)
+		case "EXP", "NBF", "IAT":
+			ok = isNonNegativeJSONNumber(raw)
+		default:
+			continue
+		}
+		if !ok {
+			return internal.Errorf("jwt: %q: %w", name, ErrClaimType)
+		}
+	}
+	return nil
+}
+
+func isJSONString(raw json.RawMessage) bool {
+	var s string
+	return bytes.HasPrefix(raw, []byte{'"'}) && json.Unmarshal(raw, &s) == nil
+}
+
+func isJSONStringArray(raw json.RawMessage) bool {
+	var arr []json.RawMessage
+	if !bytes.HasPrefix(raw, []byte{'['}) || json.Unmarshal(raw, &arr) != nil {
+		return false
+	}
+	for _, v := range arr {
+		if !isJSONString(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNonNegativeJSONNumber(raw json.RawMessage) bool {
+	var n json.Number
+	if len(raw) == 0 || raw[0] == '"' || raw[0] == '-' || json.Unmarshal(raw, &n) != nil {
+		return false
+	}
+	_, err := n.Float64()
+	return err == nil
+}
+
+// Compile-time checks.
+var _ VerifyOption = StrictClaims
@@ -0,0 +1,51 @@
+package jwt_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStrictClaims(t *testing.T) {
+	testCases := []struct {
+		name   string
+		claims map[string]interface{}
+		err    error
+	}{
+		{"valid", map[string]interface{}{
+			"iss": "foo", "sub": "bar", "jti": "baz",
+			"aud": []string{"a", "b"},
+			"exp": 1700000000, "nbf": 1700000000.5, "iat": 0,
+			"custom": 1,
+		}, nil},
+		{"aud string", map[string]interface{}{"aud": "a"}, nil},
+		{"iss number", map[string]interface{}{"iss": 1}, jwt.ErrClaimType},
+		{"sub null", map[string]interface{}{"sub": nil}, jwt.ErrClaimType},
+		{"jti array", map[string]interface{}{"jti": []string{"foo"}}, jwt.ErrClaimType},
+		{"aud mixed array", map[string]interface{}{"aud": []interface{}{"a", 1}}, jwt.ErrClaimType},
+		{"aud number", map[string]interface{}{"aud": 1}, jwt.ErrClaimType},
+		{"aud object", map[string]interface{}{"aud": map[string]string{}}, jwt.ErrClaimType},
+		{"exp string", map[string]interface{}{"exp": "1700000000"}, jwt.ErrClaimType},
+		{"nbf negative", map[string]interface{}{"nbf": -1}, jwt.ErrClaimType},
+		{"iat bool", map[string]interface{}{"iat": true}, jwt.ErrClaimType},
+		{"EXP string", map[string]interface{}{"EXP": "soon"}, jwt.ErrClaimType},
+		{"Aud number", map[string]interface{}{"Aud": 5}, jwt.ErrClaimType},
+		{"ſub number", map[string]interface{}{"ſub": 1}, jwt.ErrClaimType},
+	}
+	hs256 := jwt.NewHS256([]byte("secret"))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := jwt.Sign(tc.claims, hs256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			_, err = jwt.Verify(token, hs256, &pl, jwt.StrictClaims)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
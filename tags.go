@@ -0,0 +1,252 @@
+package jwt
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrClaimValidation is the error for a claim that fails a rule from its "jwt" struct tag.
+var ErrClaimValidation = internal.NewError("jwt: claim is invalid")
+
+// ValidateTags enables validation of struct fields according to their "jwt" tags
+// after the payload is decoded. The following comma-separated rules are supported:
+//   - required: the claim must not be empty
+//   - oneof=a,b,c: the claim (or every element of it) must be one of the listed values
+//   - min=n: the claim must be at least n, or have at least n elements when it is a string, slice or map
+//   - max=n: the claim must be at most n, or have at most n elements when it is a string, slice or map
+//
+// Errors wrap ErrClaimValidation and are returned after registered claims are validated.
+func ValidateTags(rt *RawToken) error {
+	rt.tags = true
+	return nil
+}
+
+type tagRule struct {
+	name string
+	args []string
+}
+
+func (tr tagRule) String() string {
+	if len(tr.args) == 0 {
+		return tr.name
+	}
+	return tr.name + "=" + strings.Join(tr.args, ",")
+}
+
+type taggedField struct {
+	index []int
+	claim string
+	rules []tagRule
+}
+
+var tagCache sync.Map // map[reflect.Type][]taggedField
+
+func validateTags(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return validateStruct(rv, "")
+}
+
+func validateStruct(rv reflect.Value, prefix string) error {
+	for _, tf := range taggedFields(rv.Type()) {
+		fv := rv.FieldByIndex(tf.index)
+		claim := prefix + tf.claim
+		for _, rule := range tf.rules {
+			ok, err := checkRule(fv, rule)
+			if err != nil {
+				return internal.Errorf("jwt: %s claim has invalid rule %q: %v", claim, rule, err)
+			}
+			if !ok {
+				return internal.Errorf("jwt: %s claim failed %q: %w", claim, rule, ErrClaimValidation)
+			}
+		}
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct {
+			if err := validateStruct(fv, claim+"."); err != nil {
+				return err
+			}
+		}
+	}
+	// Embedded structs share the claims namespace of their parent.
+	for i := 0; i < rv.NumField(); i++ {
+		sf := rv.Type().Field(i)
+		if !sf.Anonymous {
+			continue
+		}
+		fv := rv.Field(i)
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && fv.CanInterface() {
+			if err := validateStruct(fv, prefix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func taggedFields(typ reflect.Type) []taggedField {
+	if tfs, ok := tagCache.Load(typ); ok {
+		return tfs.([]taggedField)
+	}
+	var tfs []taggedField
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.Anonymous || sf.PkgPath != "" {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("jwt")
+		if !ok {
+			continue
+		}
+		claim := sf.Name
+		if name := strings.Split(sf.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			claim = name
+		}
+		tfs = append(tfs, taggedField{
+			index: sf.Index,
+			claim: claim,
+			rules: parseTag(tag),
+		})
+	}
+	tagCache.Store(typ, tfs)
+	return tfs
+}
+
+// parseTag parses comma-separated rules. Since "oneof" also uses commas,
+// parts that neither are a known rule nor contain an equals sign are
+// appended to the previous rule's arguments.
+func parseTag(tag string) []tagRule {
+	var rules []tagRule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if i := strings.IndexByte(part, '='); i >= 0 {
+			rules = append(rules, tagRule{name: part[:i], args: strings.Fields(part[i+1:])})
+			continue
+		}
+		if part == "required" || len(rules) == 0 {
+			rules = append(rules, tagRule{name: part})
+			continue
+		}
+		last := &rules[len(rules)-1]
+		last.args = append(last.args, part)
+	}
+	return rules
+}
+
+func checkRule(fv reflect.Value, rule tagRule) (bool, error) {
+	switch rule.name {
+	case "required":
+		return !isEmptyValue(fv), nil
+	case "oneof":
+		return checkOneOf(fv, rule.args), nil
+	case "min", "max":
+		if len(rule.args) != 1 {
+			return false, fmt.Errorf("%s takes exactly one argument", rule.name)
+		}
+		n, err := strconv.ParseFloat(rule.args[0], 64)
+		if err != nil {
+			return false, err
+		}
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				return true, nil // use "required" for mandatory claims
+			}
+			fv = fv.Elem()
+		}
+		x, ok := numericValue(fv)
+		if !ok {
+			return false, fmt.Errorf("%s is not applicable to %s", rule.name, fv.Type())
+		}
+		if rule.name == "min" {
+			return x >= n, nil
+		}
+		return x <= n, nil
+	}
+	return false, fmt.Errorf("unknown rule %q", rule.name)
+}
+
+func checkOneOf(fv reflect.Value, args []string) bool {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return true
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+		for i := 0; i < fv.Len(); i++ {
+			if !checkOneOf(fv.Index(i), args) {
+				return false
+			}
+		}
+		return true
+	}
+	if isEmptyValue(fv) {
+		return true // use "required" for mandatory claims
+	}
+	s := fmt.Sprint(fv.Interface())
+	for _, arg := range args {
+		if s == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// numericValue returns either the value of a number or the length of a string, slice or map.
+func numericValue(fv reflect.Value) (float64, bool) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return float64(fv.Len()), true
+	}
+	return 0, false
+}
+
+func isEmptyValue(fv reflect.Value) bool {
+	switch fv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return fv.IsNil()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return fv.Len() == 0
+	case reflect.Bool:
+		return !fv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return fv.Float() == 0
+	}
+	if z, ok := fv.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	return reflect.DeepEqual(fv.Interface(), reflect.Zero(fv.Type()).Interface())
+}
+
+// Compile-time checks.
+var _ VerifyOption = ValidateTags
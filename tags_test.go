@@ -0,0 +1,88 @@
+package jwt_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+type taggedPayload struct {
+	jwt.Payload
+	Role   string         `json:"role,omitempty" jwt:"required,oneof=admin,user"`
+	Scopes []string       `json:"scopes,omitempty" jwt:"min=1,max=2,oneof=read write"`
+	Level  int            `json:"level,omitempty" jwt:"min=1,max=10"`
+	Tenant *taggedTenant  `json:"tenant,omitempty" jwt:"required"`
+	Extra  map[string]int `json:"extra,omitempty"`
+}
+
+type taggedTenant struct {
+	ID string `json:"id,omitempty" jwt:"required"`
+}
+
+func TestValidateTags(t *testing.T) {
+	valid := func() taggedPayload {
+		return taggedPayload{
+			Payload: jwt.Payload{Subject: "someone"},
+			Role:    "admin",
+			Scopes:  []string{"read"},
+			Level:   5,
+			Tenant:  &taggedTenant{ID: "foo"},
+		}
+	}
+	testCases := []struct {
+		name   string
+		modify func(*taggedPayload)
+		opts   []jwt.VerifyOption
+		err    error
+	}{
+		{"valid", func(*taggedPayload) {}, nil, nil},
+		{"missing role", func(pl *taggedPayload) { pl.Role = "" }, nil, jwt.ErrClaimValidation},
+		{"invalid role", func(pl *taggedPayload) { pl.Role = "root" }, nil, jwt.ErrClaimValidation},
+		{"no scopes", func(pl *taggedPayload) { pl.Scopes = nil }, nil, jwt.ErrClaimValidation},
+		{"too many scopes", func(pl *taggedPayload) { pl.Scopes = []string{"read", "write", "read"} }, nil, jwt.ErrClaimValidation},
+		{"invalid scope", func(pl *taggedPayload) { pl.Scopes = []string{"read", "delete"} }, nil, jwt.ErrClaimValidation},
+		{"level too low", func(pl *taggedPayload) { pl.Level = 0 }, nil, jwt.ErrClaimValidation},
+		{"level too high", func(pl *taggedPayload) { pl.Level = 11 }, nil, jwt.ErrClaimValidation},
+		{"missing tenant", func(pl *taggedPayload) { pl.Tenant = nil }, nil, jwt.ErrClaimValidation},
+		{"missing tenant ID", func(pl *taggedPayload) { pl.Tenant.ID = "" }, nil, jwt.ErrClaimValidation},
+		{
+			"registered claims first",
+			func(pl *taggedPayload) { pl.Role = "" },
+			[]jwt.VerifyOption{jwt.ValidatePayload(new(jwt.Payload), jwt.SubjectValidator("someone else"))},
+			jwt.ErrSubValidation,
+		},
+	}
+	hs256 := jwt.NewHS256([]byte("secret"))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pl := valid()
+			tc.modify(&pl)
+			token, err := jwt.Sign(pl, hs256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var (
+				got  taggedPayload
+				opts = append([]jwt.VerifyOption{jwt.ValidateTags}, tc.opts...)
+			)
+			_, err = jwt.Verify(token, hs256, &got, opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("decode targets", func(t *testing.T) {
+		token, err := jwt.Sign(jwt.Payload{}, hs256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tpl taggedPayload
+		_, err = jwt.Verify(token, hs256, nil, jwt.DecodePayload(&tpl), jwt.ValidateTags)
+		if want, got := jwt.ErrClaimValidation, err; !internal.ErrorIs(got, want) {
+			t.Errorf(cmp.Diff(want, got))
+		}
+	})
+}
@@ -2,6 +2,10 @@ package jwt
 
 import (
 	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
@@ -21,6 +25,8 @@ func NumericDate(tt time.Time) *Time {
 }
 
 // MarshalJSON implements a marshaling function for time-related claims.
+// Times are always marshaled as integers. In order to marshal the registered
+// time claims with sub-second precision, use a Signer with a TimePrecision.
 func (t Time) MarshalJSON() ([]byte, error) {
 	if t.Before(internal.Epoch) {
 		return json.Marshal(0)
@@ -29,18 +35,180 @@ func (t Time) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements an unmarshaling function for time-related claims.
+// Fractional NumericDates are accepted and keep their sub-second precision.
+// NumericDates encoded as strings are rejected, unless they are registered
+// time claims decoded by a Verifier with LenientTime set.
 func (t *Time) UnmarshalJSON(b []byte) error {
-	var unix *int64
-	if err := json.Unmarshal(b, &unix); err != nil {
+	var v interface{}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
 		return err
 	}
-	if unix == nil {
+	var s string
+	switch vv := v.(type) {
+	case nil:
 		return nil
+	case json.Number:
+		s = vv.String()
+	default:
+		return &json.UnmarshalTypeError{Value: string(b), Type: timeType}
+	}
+	tt, err := parseNumericDate(s)
+	if err != nil {
+		return err
 	}
-	tt := time.Unix(*unix, 0)
 	if tt.Before(internal.Epoch) {
 		tt = internal.Epoch
 	}
 	t.Time = tt
 	return nil
 }
+
+var timeType = reflect.TypeOf(Time{})
+
+// parseNumericDate parses a decimal number of seconds since the epoch
+// without losing precision to floating-point rounding whenever possible.
+func parseNumericDate(s string) (time.Time, error) {
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		tt, ok := floatNumericDate(f)
+		if !ok {
+			return time.Time{}, numericDateError(s, strconv.ErrRange)
+		}
+		return tt, nil
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+		if !isDigits(fracPart) {
+			return time.Time{}, numericDateError(s, strconv.ErrSyntax)
+		}
+	}
+	if !isDigits(strings.TrimPrefix(intPart, "-")) {
+		return time.Time{}, numericDateError(s, strconv.ErrSyntax)
+	}
+	sec, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if fracPart == "" {
+		return time.Unix(sec, 0), nil
+	}
+	if len(fracPart) > 9 {
+		fracPart = fracPart[:9]
+	}
+	nsec, err := strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if strings.HasPrefix(intPart, "-") {
+		nsec = -nsec
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+// floatNumericDate converts a number of seconds since the epoch to a time,
+// reporting false if f is not a number or doesn't fit an int64.
+func floatNumericDate(f float64) (time.Time, bool) {
+	// float64(math.MaxInt64) rounds up to 2^63, which doesn't fit an int64.
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))), true
+}
+
+func numericDateError(s string, err error) error {
+	return &strconv.NumError{Func: "parseNumericDate", Num: s, Err: err}
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatNumericDate formats tt as a NumericDate truncated to precision,
+// which must be shorter than a second, trimming trailing zeros.
+func formatNumericDate(tt time.Time, precision time.Duration) []byte {
+	if tt.Before(internal.Epoch) {
+		return []byte("0")
+	}
+	var (
+		sec  = strconv.FormatInt(tt.Unix(), 10)
+		nsec = tt.Nanosecond()
+	)
+	nsec -= nsec % int(precision)
+	frac := strconv.Itoa(nsec + int(time.Second))[1:] // zero-padded nanoseconds
+	if frac = strings.TrimRight(frac, "0"); frac == "" {
+		return []byte(sec)
+	}
+	return []byte(sec + "." + frac)
+}
+
+// numericDateClaims are the registered claims holding NumericDates.
+var numericDateClaims = []string{"exp", "nbf", "iat"}
+
+// timeClaimer is implemented by payloads whose registered time claims can be
+// read before marshaling, such as Payload and Claims.
+type timeClaimer interface {
+	timeClaims() map[string]time.Time
+}
+
+// preciseTimeClaims replaces the registered time claims in the marshaled payload pb
+// with NumericDates truncated to precision, taken from payload itself, since Time
+// always marshals integers.
+func preciseTimeClaims(pb []byte, payload interface{}, precision time.Duration) ([]byte, error) {
+	tc, ok := payload.(timeClaimer)
+	if !ok {
+		return pb, nil
+	}
+	times := tc.timeClaims()
+	if len(times) == 0 {
+		return pb, nil
+	}
+	return replaceMembers(pb, func(name string, _ json.RawMessage) (json.RawMessage, error) {
+		if tt, ok := times[name]; ok {
+			return formatNumericDate(tt, precision), nil
+		}
+		return nil, nil
+	})
+}
+
+// unquoteTimeClaims replaces registered time claims encoded as strings,
+// such as "1700000000.5", in the payload pb with the numbers they contain.
+func unquoteTimeClaims(pb []byte) ([]byte, error) {
+	return replaceMembers(pb, func(name string, v json.RawMessage) (json.RawMessage, error) {
+		if len(v) == 0 || v[0] != '"' || !isNumericDateClaim(name) {
+			return nil, nil
+		}
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			return nil, err
+		}
+		tt, err := parseNumericDate(strings.TrimSpace(s))
+		if err != nil {
+			return nil, internal.Errorf("jwt: %q: %w", name, ErrClaimType)
+		}
+		return formatNumericDate(tt, time.Nanosecond), nil
+	})
+}
+
+func isNumericDateClaim(name string) bool {
+	for _, c := range numericDateClaims {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package jwt_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -71,3 +72,140 @@ func TestTimeUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeUnmarshalJSONFractional(t *testing.T) {
+	testCases := []struct {
+		json string
+		want time.Time
+		err  bool
+	}{
+		{`1700000000`, time.Unix(1700000000, 0), false},
+		{`1700000000.5`, time.Unix(1700000000, 5e8), false},
+		{`1700000000.123456789123`, time.Unix(1700000000, 123456789), false},
+		{`1.7e9`, time.Unix(1700000000, 0), false},
+		{`"1700000000.5"`, time.Time{}, true},
+		{`1e300`, time.Time{}, true},
+		{`-1e300`, time.Time{}, true},
+		{`9223372036854775808`, time.Time{}, true},
+		{`true`, time.Time{}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			var tt jwt.Time
+			err := json.Unmarshal([]byte(tc.json), &tt)
+			if want, got := tc.err, err != nil; got != want {
+				t.Fatalf("want error %t, got %v", want, err)
+			}
+			if want, got := tc.want, tt.Time; !got.Equal(want) {
+				t.Errorf("jwt.Time.Unmarshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestLenientTime(t *testing.T) {
+	hs256 := jwt.NewHS256([]byte("secret"))
+	testCases := []struct {
+		exp     interface{}
+		lenient bool
+		want    time.Time
+		err     bool
+	}{
+		{json.Number("1700000000.5"), false, time.Unix(1700000000, 5e8), false},
+		{"1700000000.5", false, time.Time{}, true},
+		{"1700000000.5", true, time.Unix(1700000000, 5e8), false},
+		{" 1700000000 ", true, time.Unix(1700000000, 0), false},
+		{"1700000000.-5", true, time.Time{}, true},
+		{"1700000000.", true, time.Time{}, true},
+		{"+1700000000", true, time.Time{}, true},
+		{"1e300", true, time.Time{}, true},
+		{"foo", true, time.Time{}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.exp), func(t *testing.T) {
+			token, err := jwt.Sign(jwt.Claims{"exp": tc.exp, "foo": "1700000000"}, hs256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var (
+				pl  jwt.Payload
+				raw []byte
+				vf  = jwt.Verifier{LenientTime: tc.lenient}
+			)
+			_, err = vf.Verify(token, hs256, &pl, jwt.RawPayload(&raw))
+			if want, got := tc.err, err != nil; got != want {
+				t.Fatalf("want error %t, got %v", want, err)
+			}
+			if tc.err {
+				return
+			}
+			if want, got := tc.want, pl.ExpirationTime.Time; !got.Equal(want) {
+				t.Errorf("jwt.Verifier.LenientTime mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			var cl map[string]json.RawMessage
+			if err = json.Unmarshal(raw, &cl); err != nil {
+				t.Fatal(err)
+			}
+			exp, err := json.Marshal(tc.exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := string(exp), string(cl["exp"]); got != want {
+				t.Errorf("jwt.RawPayload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestTimePrecision(t *testing.T) {
+	var (
+		hs256 = jwt.NewHS256([]byte("secret"))
+		tt    = time.Unix(1700000000, 123456789)
+	)
+	testCases := []struct {
+		precision time.Duration
+		want      string
+	}{
+		{0, "1700000000"},
+		{time.Second, "1700000000"},
+		{time.Millisecond, "1700000000.123"},
+		{time.Microsecond, "1700000000.123456"},
+		{time.Nanosecond, "1700000000.123456789"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.precision.String(), func(t *testing.T) {
+			sg := jwt.Signer{TimePrecision: tc.precision}
+			for _, payload := range []interface{}{
+				jwt.Payload{ExpirationTime: &jwt.Time{tt}, Subject: "someone"},
+				&testPayload{Payload: jwt.Payload{ExpirationTime: &jwt.Time{tt}}, String: "foobar"},
+				jwt.Claims{"exp": &jwt.Time{tt}, "foo": "bar"},
+			} {
+				token, err := sg.Sign(payload, hs256)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var raw []byte
+				if _, err = jwt.Verify(token, hs256, nil, jwt.RawPayload(&raw)); err != nil {
+					t.Fatal(err)
+				}
+				var cl map[string]json.RawMessage
+				if err = json.Unmarshal(raw, &cl); err != nil {
+					t.Fatal(err)
+				}
+				if want, got := tc.want, string(cl["exp"]); got != want {
+					t.Errorf("jwt.Signer.TimePrecision mismatch for %T (-want +got):\n%s", payload, cmp.Diff(want, got))
+				}
+				if want, got := 2, len(cl); got != want {
+					t.Errorf("jwt.Signer.TimePrecision claims mismatch for %T (-want +got):\n%s", payload, cmp.Diff(want, got))
+				}
+				b, err := json.Marshal(jwt.Time{tt})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want, got := "1700000000", string(b); got != want {
+					t.Errorf("jwt.Time.Marshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}
+		})
+	}
+}
@@ -26,6 +26,15 @@ var (
 // Validator is a function that validates a Payload pointer.
 type Validator func(*Payload) error
 
+// PayloadValidator is a function that validates the whole decoded payload,
+// which allows checking private claims. It receives the same value passed to Verify
+// or, if that is nil, the payload decoded as Claims.
+type PayloadValidator func(payload interface{}) error
+
+// ClaimsValidator is a function that validates the payload decoded as Claims,
+// regardless of the value passed to Verify.
+type ClaimsValidator func(Claims) error
+
 // AudienceValidator validates the "aud" claim.
 // It checks if at least one of the audiences in the JWT's payload is listed in aud.
 func AudienceValidator(aud Audience) Validator {
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrTooLarge is the error for when a token or one of its parts exceeds a Verifier's size limit.
+var ErrTooLarge = internal.NewError("jwt: token exceeds size limit")
+
+var defaultVerifier Verifier
+
+// Verifier verifies tokens according to parsing limits and rules.
+// Its zero value behaves exactly like Verify.
+type Verifier struct {
+	// MaxTokenSize is the maximum length of an encoded token, in bytes.
+	MaxTokenSize int
+	// MaxHeaderSize is the maximum length of a decoded header, in bytes.
+	MaxHeaderSize int
+	// MaxPayloadSize is the maximum length of a decoded payload, in bytes.
+	MaxPayloadSize int
+
+	// Strict enables strict parsing, which rejects tokens that don't have
+	// exactly three parts, whose Base64 encoding is not canonical, or whose
+	// header or payload contain duplicate JSON members. Since encoding/json
+	// matches members case-insensitively, members differing only in case are
+	// also considered duplicates.
+	Strict bool
+
+	// Lenient enables normalizing tokens before parsing them. Surrounding whitespace,
+	// the "Bearer" scheme, Base64 padding and standard Base64 characters are removed or
+	// replaced, so the signature is verified against the token's canonical form.
+	// Applied fixes can be retrieved with ReportNormalization.
+	Lenient bool
+
+	// Codec unmarshals payloads. If nil, DefaultCodec is used.
+	// Headers are always unmarshaled with encoding/json.
+	Codec Codec
+
+	// LenientTime makes the "exp", "nbf" and "iat" claims also be accepted
+	// when they are NumericDates encoded as JSON strings, such as "1700000000.5".
+	LenientTime bool
+}
+
+// Verify verifies a token the same way Verify does, but according to vf's limits and rules.
+// Limits are checked before anything is decoded.
+func (vf *Verifier) Verify(token []byte, alg Algorithm, payload interface{}, opts ...VerifyOption) (Header, error) {
+	return vf.VerifyContext(context.Background(), token, alg, payload, opts...)
+}
+
+// VerifyContext verifies a token the same way VerifyContext does, but according to vf's limits and rules.
+func (vf *Verifier) VerifyContext(ctx context.Context, token []byte, alg Algorithm, payload interface{}, opts ...VerifyOption) (Header, error) {
+	rt := &RawToken{
+		alg: alg,
+		vf:  vf,
+	}
+
+	if vf.MaxTokenSize > 0 && len(token) > vf.MaxTokenSize {
+		return rt.hd, ErrTooLarge
+	}
+	if vf.Lenient {
+		token, rt.norm = normalize(token)
+	}
+	sep1 := bytes.IndexByte(token, '.')
+	if sep1 < 0 {
+		return rt.hd, ErrMalformed
+	}
+
+	cbytes := token[sep1+1:]
+	sep2 := bytes.IndexByte(cbytes, '.')
+	if sep2 < 0 {
+		return rt.hd, ErrMalformed
+	}
+	rt.setToken(token, sep1, sep2)
+
+	var err error
+	if err = vf.check(rt); err != nil {
+		return rt.hd, err
+	}
+	if err = rt.decodeHeader(); err != nil {
+		return rt.hd, err
+	}
+	if err = resolve(ctx, alg, rt.hd); err != nil {
+		return rt.hd, err
+	}
+	for _, opt := range opts {
+		if err = opt(rt); err != nil {
+			return rt.hd, err
+		}
+	}
+	if err = WithContext(alg).VerifyContext(ctx, rt.headerPayload(), rt.sig()); err != nil {
+		return rt.hd, err
+	}
+	return rt.hd, rt.decode(payload)
+}
+
+func (vf *Verifier) check(rt *RawToken) error {
+	enc := base64.RawURLEncoding
+	if vf.MaxHeaderSize > 0 && enc.DecodedLen(len(rt.header())) > vf.MaxHeaderSize {
+		return internal.Errorf("jwt: header: %w", ErrTooLarge)
+	}
+	if vf.MaxPayloadSize > 0 && enc.DecodedLen(len(rt.payload())) > vf.MaxPayloadSize {
+		return internal.Errorf("jwt: payload: %w", ErrTooLarge)
+	}
+	if !vf.Strict {
+		return nil
+	}
+	if bytes.IndexByte(rt.sig(), '.') >= 0 {
+		return ErrMalformed
+	}
+	// Algorithms decode the signature leniently, so check it's canonical beforehand.
+	_, err := internal.DecodeToBytesStrict(rt.sig())
+	return err
+}
@@ -0,0 +1,92 @@
+package jwt_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVerifier(t *testing.T) {
+	var (
+		hs256 = jwt.NewHS256([]byte("secret"))
+		enc   = base64.RawURLEncoding
+		sign  = func(header, payload string) string {
+			hp := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload))
+			sig, err := hs256.Sign([]byte(hp))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return hp + "." + enc.EncodeToString(sig)
+		}
+		header = `{"alg":"HS256","typ":"JWT"}`
+		valid  = sign(header, `{"sub":"someone"}`)
+	)
+	testCases := []struct {
+		name  string
+		vf    jwt.Verifier
+		token string
+		err   error
+	}{
+		{"zero value", jwt.Verifier{}, valid, nil},
+		{"max token size", jwt.Verifier{MaxTokenSize: len(valid)}, valid, nil},
+		{"token too large", jwt.Verifier{MaxTokenSize: len(valid) - 1}, valid, jwt.ErrTooLarge},
+		{"header too large", jwt.Verifier{MaxHeaderSize: len(header) - 1}, valid, jwt.ErrTooLarge},
+		{"payload too large", jwt.Verifier{MaxPayloadSize: 4}, valid, jwt.ErrTooLarge},
+		{"strict", jwt.Verifier{Strict: true}, valid, nil},
+		{"strict extra segment", jwt.Verifier{Strict: true}, valid + ".foo", jwt.ErrMalformed},
+		{"duplicate claim", jwt.Verifier{}, sign(header, `{"sub":"foo","sub":"bar"}`), nil},
+		{"strict duplicate claim", jwt.Verifier{Strict: true}, sign(header, `{"sub":"foo","sub":"bar"}`), jwt.ErrDuplicateMember},
+		{"strict duplicate claim case", jwt.Verifier{Strict: true}, sign(header, `{"sub":"foo","SUB":"bar"}`), jwt.ErrDuplicateMember},
+		{"strict duplicate claim folding", jwt.Verifier{Strict: true}, sign(header, `{"sub":"alice","ſub":"admin"}`), jwt.ErrDuplicateMember},
+		{"strict duplicate claim Kelvin", jwt.Verifier{Strict: true}, sign(header, `{"kid":"a","Kid":"b"}`), jwt.ErrDuplicateMember},
+		{"strict duplicate nested", jwt.Verifier{Strict: true}, sign(header, `{"a":[{"b":1,"b":2}]}`), jwt.ErrDuplicateMember},
+		{"strict duplicate header", jwt.Verifier{Strict: true}, sign(`{"alg":"HS256","alg":"none"}`, `{}`), jwt.ErrDuplicateMember},
+		{"strict distinct nested", jwt.Verifier{Strict: true}, sign(header, `{"a":{"b":1},"b":{"a":1}}`), nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pl jwt.Payload
+			_, err := tc.vf.Verify([]byte(tc.token), hs256, &pl)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("strict non-canonical Base64", func(t *testing.T) {
+		// "e30" is "{}", while "e31" decodes to the same bytes with non-zero trailing bits.
+		hp := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.e31"
+		sig, err := hs256.Sign([]byte(hp))
+		if err != nil {
+			t.Fatal(err)
+		}
+		token := []byte(hp + "." + enc.EncodeToString(sig))
+		var pl jwt.Payload
+		if _, err = jwt.Verify(token, hs256, &pl); err != nil {
+			t.Fatalf("jwt.Verify rejected non-canonical token: %v", err)
+		}
+		_, err = (&jwt.Verifier{Strict: true}).Verify(token, hs256, &pl)
+		if !internal.ErrorAs(err, new(base64.CorruptInputError)) {
+			t.Errorf("want %T, got %v", base64.CorruptInputError(0), err)
+		}
+	})
+
+	t.Run("strict newlines in Base64", func(t *testing.T) {
+		for _, payload := range []string{"e3\n0", "e3\r0", "e3\r\n0"} {
+			hp := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." + payload
+			sig, err := hs256.Sign([]byte(hp))
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := []byte(hp + "." + enc.EncodeToString(sig))
+			var pl jwt.Payload
+			_, err = (&jwt.Verifier{Strict: true}).Verify(token, hs256, &pl)
+			if !internal.ErrorAs(err, new(base64.CorruptInputError)) {
+				t.Errorf("%q: want %T, got %v", payload, base64.CorruptInputError(0), err)
+			}
+		}
+	})
+}
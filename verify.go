@@ -1,7 +1,7 @@
 package jwt
 
 import (
-	"bytes"
+	"context"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -14,50 +14,41 @@ type VerifyOption func(*RawToken) error
 
 // Verify verifies a token's signature using alg. Before verification, opts is iterated and
 // each option in it is run.
+//
+// After verification, the token's payload is decoded into payload, which may be nil
+// when decoding is done solely by options such as DecodePayload.
+//
+// Verify doesn't limit sizes nor enforce strict parsing. In order to do so, use a Verifier.
 func Verify(token []byte, alg Algorithm, payload interface{}, opts ...VerifyOption) (Header, error) {
-	rt := &RawToken{
-		alg: alg,
-	}
-
-	sep1 := bytes.IndexByte(token, '.')
-	if sep1 < 0 {
-		return rt.hd, ErrMalformed
-	}
+	return defaultVerifier.VerifyContext(context.Background(), token, alg, payload, opts...)
+}
 
-	cbytes := token[sep1+1:]
-	sep2 := bytes.IndexByte(cbytes, '.')
-	if sep2 < 0 {
-		return rt.hd, ErrMalformed
-	}
-	rt.setToken(token, sep1, sep2)
+// VerifyContext verifies a token the same way Verify does, but passing ctx to alg if it is
+// a ContextAlgorithm or a ContextResolver, so fetching keys can be canceled.
+func VerifyContext(ctx context.Context, token []byte, alg Algorithm, payload interface{}, opts ...VerifyOption) (Header, error) {
+	return defaultVerifier.VerifyContext(ctx, token, alg, payload, opts...)
+}
 
-	var err error
-	if err = rt.decodeHeader(); err != nil {
-		return rt.hd, err
-	}
-	if rv, ok := alg.(Resolver); ok {
-		if err = rv.Resolve(rt.hd); err != nil {
-			return rt.hd, err
-		}
+// ValidateHeader checks whether the algorithm contained
+// in the JOSE header is the same used by the algorithm,
+// or one of its aliases, as with EdDSA and Ed25519.
+func ValidateHeader(rt *RawToken) error {
+	if rt.alg.Name() == rt.hd.Algorithm {
+		return nil
 	}
-	for _, opt := range opts {
-		if err = opt(rt); err != nil {
-			return rt.hd, err
+	if a, ok := rt.alg.(aliaser); ok {
+		for _, name := range a.Aliases() {
+			if name == rt.hd.Algorithm {
+				return nil
+			}
 		}
 	}
-	if err = alg.Verify(rt.headerPayload(), rt.sig()); err != nil {
-		return rt.hd, err
-	}
-	return rt.hd, rt.decode(payload)
+	return internal.Errorf("jwt: %q: %w", rt.hd.Algorithm, ErrAlgValidation)
 }
 
-// ValidateHeader checks whether the algorithm contained
-// in the JOSE header is the same used by the algorithm.
-func ValidateHeader(rt *RawToken) error {
-	if rt.alg.Name() != rt.hd.Algorithm {
-		return internal.Errorf("jwt: %q: %w", rt.hd.Algorithm, ErrAlgValidation)
-	}
-	return nil
+// aliaser is implemented by algorithms that are also known by other names, such as EdDSA.
+type aliaser interface {
+	Aliases() []string
 }
 
 // ValidatePayload runs validators against a Payload after it's been decoded.
@@ -69,5 +60,44 @@ func ValidatePayload(pl *Payload, vds ...Validator) VerifyOption {
 	}
 }
 
+// ValidateClaims runs validators against the decoded payload after
+// registered claims and struct tags are validated. Validators are run
+// in order and the first error is returned.
+func ValidateClaims(vds ...PayloadValidator) VerifyOption {
+	return func(rt *RawToken) error {
+		rt.pvds = append(rt.pvds, vds...)
+		return nil
+	}
+}
+
+// ValidateRawClaims runs validators against the payload decoded as Claims,
+// after the validators set by ValidateClaims. Validators are run in order
+// and the first error is returned.
+func ValidateRawClaims(vds ...ClaimsValidator) VerifyOption {
+	return func(rt *RawToken) error {
+		rt.cvds = append(rt.cvds, vds...)
+		return nil
+	}
+}
+
+// DecodePayload decodes the payload into each one of targets, in addition to
+// the payload passed to Verify. The payload is Base64-decoded only once, and map targets,
+// such as *Claims, are copied from a single decoding of it, so its JSON is parsed only once
+// for all of them. Nested objects and arrays are shared between map targets.
+func DecodePayload(targets ...interface{}) VerifyOption {
+	return func(rt *RawToken) error {
+		rt.targets = append(rt.targets, targets...)
+		return nil
+	}
+}
+
+// RawPayload sets b to the decoded JSON payload after verification.
+func RawPayload(b *[]byte) VerifyOption {
+	return func(rt *RawToken) error {
+		rt.raw = b
+		return nil
+	}
+}
+
 // Compile-time checks.
 var _ VerifyOption = ValidateHeader
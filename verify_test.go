@@ -1,6 +1,8 @@
 package jwt_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -53,6 +55,7 @@ func TestVerify(t *testing.T) {
 		"RSA-PSS": rsaPSSTestCases,
 		"ECDSA":   ecdsaTestCases,
 		"Ed25519": ed25519TestCases,
+		"EdDSA":   eddsaTestCases,
 	}
 	for k, v := range testCases {
 		t.Run(k, func(t *testing.T) {
@@ -90,6 +93,18 @@ func TestVerify(t *testing.T) {
 			t.Errorf("jwt.Verify JSON payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
 		}
 	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		var (
+			header = "eyJ0eXAiOiJKV1QiLCJhbGciOiJub25lIn0" // {"typ":"JWT","alg":"none"}
+			token  = fmt.Sprintf("%s..", header)
+			v      interface{}
+		)
+		_, err := jwt.Verify([]byte(token), jwt.None(), &v)
+		if want, got := jwt.ErrNotJSONObject, err; !internal.ErrorIs(got, want) {
+			t.Errorf("jwt.Verify JSON payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
 }
 
 func TestValidatePayload(t *testing.T) {
@@ -161,3 +176,175 @@ func TestValidatePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodePayload(t *testing.T) {
+	hs256 := jwt.NewHS256([]byte("secret"))
+	token, err := jwt.Sign(tp, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("multiple targets", func(t *testing.T) {
+		var (
+			pl  jwt.Payload
+			tpl testPayload
+			cl  jwt.Claims
+			raw []byte
+		)
+		_, err := jwt.Verify(token, hs256, &pl, jwt.DecodePayload(&tpl, &cl), jwt.RawPayload(&raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := tp.Payload, pl; !cmp.Equal(got, want) {
+			t.Errorf("jwt.Verify payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		if want, got := tp, tpl; !cmp.Equal(got, want) {
+			t.Errorf("jwt.DecodePayload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		if want, got := tp.String, cl["string"]; got != want {
+			t.Errorf("jwt.DecodePayload claims mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		var rawPl testPayload
+		if err = json.Unmarshal(raw, &rawPl); err != nil {
+			t.Fatal(err)
+		}
+		if want, got := tp, rawPl; !cmp.Equal(got, want) {
+			t.Errorf("jwt.RawPayload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("map targets", func(t *testing.T) {
+		var (
+			cc  = new(countingCodec)
+			cl  jwt.Claims
+			cl2 jwt.Claims
+			m   map[string]interface{}
+		)
+		_, err := (&jwt.Verifier{Codec: cc}).Verify(token, hs256, &cl, jwt.DecodePayload(&cl2, &m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The payload is unmarshaled only once.
+		if want, got := 1, cc.unmarshals; got != want {
+			t.Errorf("codec calls mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		cl["string"] = "changed"
+		if want, got := tp.String, cl2["string"]; got != want {
+			t.Errorf("jwt.DecodePayload claims mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		if want, got := tp.String, m["string"]; got != want {
+			t.Errorf("jwt.DecodePayload map mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("nil payload", func(t *testing.T) {
+		var raw []byte
+		if _, err := jwt.Verify(token, hs256, nil, jwt.RawPayload(&raw)); err != nil {
+			t.Fatal(err)
+		}
+		if len(raw) == 0 {
+			t.Errorf("jwt.RawPayload is empty")
+		}
+	})
+
+	t.Run("failed verification", func(t *testing.T) {
+		var raw []byte
+		_, err := jwt.Verify(token, jwt.NewHS256([]byte("terces")), nil, jwt.RawPayload(&raw))
+		if want, got := jwt.ErrHMACVerification, err; !internal.ErrorIs(got, want) {
+			t.Errorf(cmp.Diff(want, got))
+		}
+		if raw != nil {
+			t.Errorf("jwt.RawPayload set for unverified token")
+		}
+	})
+}
+
+func TestValidateClaims(t *testing.T) {
+	var (
+		errInt    = errors.New("int claim is too low")
+		errString = errors.New("string claim is invalid")
+		intVd     = func(min int) jwt.PayloadValidator {
+			return func(v interface{}) error {
+				if v.(*testPayload).Int < min {
+					return errInt
+				}
+				return nil
+			}
+		}
+		stringVd = func(s string) jwt.PayloadValidator {
+			return func(v interface{}) error {
+				if v.(*testPayload).String != s {
+					return errString
+				}
+				return nil
+			}
+		}
+	)
+	testCases := []struct {
+		vds  []jwt.Validator
+		pvds []jwt.PayloadValidator
+		err  error
+	}{
+		{nil, []jwt.PayloadValidator{intVd(1000), stringVd("foobar")}, nil},
+		{nil, []jwt.PayloadValidator{intVd(2000), stringVd("foobar")}, errInt},
+		{nil, []jwt.PayloadValidator{intVd(1000), stringVd("barfoo")}, errString},
+		{nil, []jwt.PayloadValidator{intVd(2000), stringVd("barfoo")}, errInt},
+		{[]jwt.Validator{jwt.IssuerValidator("someone")}, []jwt.PayloadValidator{intVd(2000)}, jwt.ErrIssValidation},
+	}
+	hs256 := jwt.NewHS256([]byte("secret"))
+	token, err := jwt.Sign(tp, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			var pl testPayload
+			_, err := jwt.Verify(token, hs256, &pl,
+				jwt.ValidatePayload(&pl.Payload, tc.vds...),
+				jwt.ValidateClaims(tc.pvds...),
+			)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("nil payload", func(t *testing.T) {
+		var got interface{}
+		_, err := jwt.Verify(token, hs256, nil, jwt.ValidateClaims(func(v interface{}) error {
+			got = v
+			return nil
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cl, ok := got.(jwt.Claims)
+		if !ok {
+			t.Fatalf("jwt.PayloadValidator got %T, want jwt.Claims", got)
+		}
+		if want, got := tp.String, cl["string"]; got != want {
+			t.Errorf("jwt.PayloadValidator claims mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("raw claims", func(t *testing.T) {
+		rawVd := func(s string) jwt.ClaimsValidator {
+			return func(cl jwt.Claims) error {
+				if v, err := cl.String("string"); err != nil || v != s {
+					return errString
+				}
+				return nil
+			}
+		}
+		for _, payload := range []interface{}{nil, new(testPayload)} {
+			_, err := jwt.Verify(token, hs256, payload, jwt.ValidateRawClaims(rawVd("foobar")))
+			if err != nil {
+				t.Errorf("jwt.ValidateRawClaims with %T: %v", payload, err)
+			}
+			_, err = jwt.Verify(token, hs256, payload, jwt.ValidateRawClaims(rawVd("barfoo")))
+			if want, got := errString, err; !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		}
+	})
+}